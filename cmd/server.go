@@ -4,7 +4,9 @@ import (
 	"codebase-app/internal/adapter"
 	"codebase-app/internal/infrastructure"
 	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/middleware"
 	"codebase-app/internal/route"
+	"codebase-app/pkg/jwthandler"
 	"codebase-app/pkg/validator"
 	"flag"
 	"os"
@@ -60,11 +62,24 @@ func RunServer(cmd *flag.FlagSet, args []string) {
 	}))
 	// End Application Middlewares
 
-	adapter.Adapters.Sync(
+	adapters := []adapter.Option{
 		adapter.WithRestServer(app),
 		adapter.WithShopeefunPostgres(),
 		adapter.WithValidator(validator.NewValidator()),
-	)
+	}
+	if envs.App.StorageDriver == "spaces" {
+		adapters = append(adapters, adapter.WithDigihubStorage())
+	}
+
+	adapter.Adapters.Sync(adapters...)
+
+	if err := middleware.CheckGatewayTrust(); err != nil {
+		log.Fatal().Err(err).Str("mode", envs.Gateway.TrustMode).Msg("Error while checking the gateway trust settings")
+	}
+
+	if err := jwthandler.LoadKeys(); err != nil {
+		log.Fatal().Err(err).Msg("Error while loading the JWT keys")
+	}
 
 	infrastructure.InitializeLogger(envs.App.Environtment, envs.App.LogFile, logLevel)
 	app.Get("/metrics", monitor.New(monitor.Config{Title: config.Envs.App.Name + config.Envs.App.Environtment + " Metrics"}))
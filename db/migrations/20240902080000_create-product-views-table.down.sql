@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS products_view_count_idx;
+DROP INDEX IF EXISTS products_popularity_score_idx;
+DROP TABLE IF EXISTS product_views_daily;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS popularity_score,
+    DROP COLUMN IF EXISTS view_count;
@@ -0,0 +1,15 @@
+ALTER TABLE products
+    ADD COLUMN view_count BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN popularity_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS product_views_daily (
+    product_id UUID NOT NULL,
+    view_date DATE NOT NULL,
+    views INT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (product_id, view_date),
+    FOREIGN KEY (product_id) REFERENCES products (id)
+);
+
+CREATE INDEX IF NOT EXISTS products_popularity_score_idx ON products (popularity_score DESC);
+CREATE INDEX IF NOT EXISTS products_view_count_idx ON products (view_count DESC);
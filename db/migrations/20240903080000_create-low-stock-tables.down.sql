@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS stock_notifications;
+DROP TABLE IF EXISTS domain_events;
+ALTER TABLE products DROP COLUMN IF EXISTS low_stock_threshold;
+ALTER TABLE shops DROP COLUMN IF EXISTS low_stock_threshold;
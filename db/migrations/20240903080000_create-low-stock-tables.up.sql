@@ -0,0 +1,33 @@
+ALTER TABLE shops
+    ADD COLUMN low_stock_threshold INT NOT NULL DEFAULT 5;
+
+ALTER TABLE products
+    ADD COLUMN low_stock_threshold INT;
+
+CREATE TABLE IF NOT EXISTS domain_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS domain_events_unpublished_idx ON domain_events (created_at) WHERE published_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS stock_notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    stock INT NOT NULL,
+    threshold INT NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+
+    FOREIGN KEY (shop_id) REFERENCES shops (id),
+    FOREIGN KEY (product_id) REFERENCES products (id)
+);
+
+CREATE INDEX IF NOT EXISTS stock_notifications_shop_id_idx ON stock_notifications (shop_id, created_at DESC);
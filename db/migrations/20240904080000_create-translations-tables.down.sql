@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS category_translations;
+DROP TABLE IF EXISTS shop_translations;
+DROP TABLE IF EXISTS product_translations;
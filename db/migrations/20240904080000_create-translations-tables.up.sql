@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS product_translations (
+    product_id UUID NOT NULL,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (product_id, locale),
+    FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS shop_translations (
+    shop_id UUID NOT NULL,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    terms TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (shop_id, locale),
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS category_translations (
+    category_id UUID NOT NULL,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (category_id, locale),
+    FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS related_exclusions;
+DROP TABLE IF EXISTS product_tags;
+DROP INDEX IF EXISTS products_name_trgm_idx;
@@ -0,0 +1,26 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS products_name_trgm_idx ON products USING gin (name gin_trgm_ops);
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id UUID NOT NULL,
+    tag VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (product_id, tag),
+    FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS product_tags_tag_idx ON product_tags (tag);
+
+-- products or categories a shop never wants recommended on its product pages
+CREATE TABLE IF NOT EXISTS related_exclusions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('product', 'category')),
+    target_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    UNIQUE (shop_id, type, target_id),
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+ALTER TABLE shops
+    DROP COLUMN IF EXISTS logo_url,
+    DROP COLUMN IF EXISTS banner_url,
+    DROP COLUMN IF EXISTS accent_color,
+    DROP COLUMN IF EXISTS social_links;
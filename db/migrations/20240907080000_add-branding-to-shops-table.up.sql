@@ -0,0 +1,5 @@
+ALTER TABLE shops
+    ADD COLUMN IF NOT EXISTS logo_url VARCHAR(2048),
+    ADD COLUMN IF NOT EXISTS banner_url VARCHAR(2048),
+    ADD COLUMN IF NOT EXISTS accent_color VARCHAR(7),
+    ADD COLUMN IF NOT EXISTS social_links JSONB NOT NULL DEFAULT '{}';
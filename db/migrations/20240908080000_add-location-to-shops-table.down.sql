@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS shops_location_idx;
+
+ALTER TABLE shops
+    DROP COLUMN IF EXISTS location,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS city,
+    DROP COLUMN IF EXISTS province,
+    DROP COLUMN IF EXISTS postal_code;
@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+ALTER TABLE shops
+    ADD COLUMN IF NOT EXISTS location geography(Point, 4326),
+    ADD COLUMN IF NOT EXISTS address VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS city VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS province VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS postal_code VARCHAR(10);
+
+CREATE INDEX IF NOT EXISTS shops_location_idx ON shops USING gist (location);
@@ -0,0 +1,9 @@
+DROP FUNCTION IF EXISTS shop_is_open(UUID, TIMESTAMPTZ);
+DROP TABLE IF EXISTS shop_operating_hours;
+
+ALTER TABLE shops
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS vacation_mode,
+    DROP COLUMN IF EXISTS vacation_start,
+    DROP COLUMN IF EXISTS vacation_end,
+    DROP COLUMN IF EXISTS vacation_message;
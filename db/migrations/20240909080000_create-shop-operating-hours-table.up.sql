@@ -0,0 +1,43 @@
+ALTER TABLE shops
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta',
+    ADD COLUMN IF NOT EXISTS vacation_mode BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS vacation_start DATE,
+    ADD COLUMN IF NOT EXISTS vacation_end DATE,
+    ADD COLUMN IF NOT EXISTS vacation_message VARCHAR(255);
+
+-- a shop without any row is open all the time
+CREATE TABLE IF NOT EXISTS shop_operating_hours (
+    shop_id UUID NOT NULL,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6), -- 0 is sunday
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL CHECK (closes_at > opens_at),
+
+    PRIMARY KEY (shop_id, weekday),
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
+
+-- shop_is_open tells whether the shop takes orders at the given time, in the shop timezone.
+-- The vacation dates are inclusive, a vacation without start date starts right away.
+CREATE OR REPLACE FUNCTION shop_is_open(p_shop_id UUID, p_at TIMESTAMPTZ DEFAULT now())
+RETURNS BOOLEAN AS $$
+    SELECT
+        NOT (
+            s.vacation_mode
+            AND (p_at AT TIME ZONE s.timezone)::date >= COALESCE(s.vacation_start, '-infinity'::date)
+            AND (p_at AT TIME ZONE s.timezone)::date <= COALESCE(s.vacation_end, 'infinity'::date)
+        )
+        AND (
+            NOT EXISTS (SELECT 1 FROM shop_operating_hours h WHERE h.shop_id = s.id)
+            OR EXISTS (
+                SELECT 1
+                FROM shop_operating_hours h
+                WHERE
+                    h.shop_id = s.id
+                    AND h.weekday = EXTRACT(DOW FROM p_at AT TIME ZONE s.timezone)
+                    AND (p_at AT TIME ZONE s.timezone)::time >= h.opens_at
+                    AND (p_at AT TIME ZONE s.timezone)::time < h.closes_at
+            )
+        )
+    FROM shops s
+    WHERE s.id = p_shop_id
+$$ LANGUAGE sql STABLE;
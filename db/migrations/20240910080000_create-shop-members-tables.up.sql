@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS shop_members (
+    shop_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'inventory_clerk', 'viewer')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (shop_id, user_id),
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS shop_members_user_id_idx ON shop_members (user_id);
+
+-- every existing shop keeps its creator as owner
+INSERT INTO shop_members (shop_id, user_id, role)
+SELECT id, user_id, 'owner'
+FROM shops
+ON CONFLICT (shop_id, user_id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS shop_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('manager', 'inventory_clerk', 'viewer')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    invited_by UUID NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
+
+-- one pending invitation per user and shop, inviting again refreshes it
+CREATE UNIQUE INDEX IF NOT EXISTS shop_invitations_pending_idx ON shop_invitations (shop_id, user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS shop_invitations_user_id_idx ON shop_invitations (user_id, status);
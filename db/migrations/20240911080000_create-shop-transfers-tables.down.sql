@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS shop_audit_logs;
+DROP TABLE IF EXISTS shop_transfers;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS shop_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    from_user_id UUID NOT NULL,
+    to_user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled', 'expired')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE,
+    CHECK (from_user_id <> to_user_id)
+);
+
+-- a shop has at most one pending transfer at a time
+CREATE UNIQUE INDEX IF NOT EXISTS shop_transfers_pending_idx ON shop_transfers (shop_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS shop_transfers_to_user_id_idx ON shop_transfers (to_user_id, status);
+
+CREATE TABLE IF NOT EXISTS shop_audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS shop_audit_logs_shop_id_idx ON shop_audit_logs (shop_id, created_at DESC);
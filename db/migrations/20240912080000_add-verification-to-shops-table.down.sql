@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS shop_verification_history;
+
+DROP INDEX IF EXISTS shops_verification_status_idx;
+
+ALTER TABLE shops
+    DROP COLUMN IF EXISTS verification_status,
+    DROP COLUMN IF EXISTS verification_reason,
+    DROP COLUMN IF EXISTS verification_updated_at;
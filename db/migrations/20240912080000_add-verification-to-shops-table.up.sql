@@ -0,0 +1,20 @@
+-- NULL means the shop never asked to be verified
+ALTER TABLE shops
+    ADD COLUMN IF NOT EXISTS verification_status VARCHAR(20) CHECK (verification_status IN ('pending', 'verified', 'rejected', 'suspended')),
+    ADD COLUMN IF NOT EXISTS verification_reason TEXT,
+    ADD COLUMN IF NOT EXISTS verification_updated_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS shops_verification_status_idx ON shops (verification_status) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS shop_verification_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'verified', 'rejected', 'suspended')),
+    reason TEXT,
+    actor_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS shop_verification_history_shop_id_idx ON shop_verification_history (shop_id, created_at DESC);
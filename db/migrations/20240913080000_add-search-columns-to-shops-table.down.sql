@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS products_shop_id_category_id_idx;
+DROP INDEX IF EXISTS shops_city_idx;
+
+ALTER TABLE shops
+    DROP COLUMN IF EXISTS rating_average,
+    DROP COLUMN IF EXISTS rating_count;
@@ -0,0 +1,8 @@
+-- rating_average and rating_count are denormalized for the shop directory,
+-- they stay at zero until shop reviews are recorded
+ALTER TABLE shops
+    ADD COLUMN IF NOT EXISTS rating_average NUMERIC(3, 2) NOT NULL DEFAULT 0 CHECK (rating_average BETWEEN 0 AND 5),
+    ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0 CHECK (rating_count >= 0);
+
+CREATE INDEX IF NOT EXISTS shops_city_idx ON shops (lower(city)) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS products_shop_id_category_id_idx ON products (shop_id, category_id) WHERE deleted_at IS NULL;
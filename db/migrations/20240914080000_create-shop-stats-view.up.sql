@@ -0,0 +1,23 @@
+-- per shop product aggregates for the seller dashboard, refreshed by the application
+-- so the dashboard never scans the products of a large shop
+CREATE MATERIALIZED VIEW IF NOT EXISTS shop_stats AS
+SELECT
+    s.id AS shop_id,
+    COUNT(p.id) FILTER (WHERE p.deleted_at IS NULL) AS product_count,
+    COUNT(p.id) FILTER (WHERE p.deleted_at IS NULL AND p.stock > COALESCE(p.low_stock_threshold, s.low_stock_threshold)) AS in_stock_count,
+    COUNT(p.id) FILTER (WHERE p.deleted_at IS NULL AND p.stock > 0 AND p.stock <= COALESCE(p.low_stock_threshold, s.low_stock_threshold)) AS low_stock_count,
+    COUNT(p.id) FILTER (WHERE p.deleted_at IS NULL AND p.stock <= 0) AS out_of_stock_count,
+    COUNT(p.id) FILTER (WHERE p.deleted_at IS NOT NULL) AS deleted_count,
+    COALESCE(SUM(p.stock) FILTER (WHERE p.deleted_at IS NULL), 0)::bigint AS inventory_units,
+    COALESCE(SUM(p.price::bigint * p.stock) FILTER (WHERE p.deleted_at IS NULL), 0)::bigint AS inventory_value,
+    COALESCE(SUM(p.view_count) FILTER (WHERE p.deleted_at IS NULL), 0)::bigint AS total_views,
+    now() AS refreshed_at
+FROM shops s
+LEFT JOIN
+    products p ON p.shop_id = s.id
+WHERE
+    s.deleted_at IS NULL
+GROUP BY s.id;
+
+-- required by REFRESH MATERIALIZED VIEW CONCURRENTLY
+CREATE UNIQUE INDEX IF NOT EXISTS shop_stats_shop_id_idx ON shop_stats (shop_id);
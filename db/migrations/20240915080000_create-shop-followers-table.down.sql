@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS products_shop_id_created_at_idx;
+DROP TABLE IF EXISTS shop_followers;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS shop_followers (
+    shop_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (shop_id, user_id),
+    FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS shop_followers_user_id_idx ON shop_followers (user_id);
+
+-- the follower feed walks the newest products of a few shops
+CREATE INDEX IF NOT EXISTS products_shop_id_created_at_idx ON products (shop_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
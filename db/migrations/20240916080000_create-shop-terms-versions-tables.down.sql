@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS shop_terms_acceptances;
+DROP TABLE IF EXISTS shop_terms_versions;
+
+ALTER TABLE shops
+    DROP COLUMN IF EXISTS terms_version;
@@ -0,0 +1,35 @@
+ALTER TABLE shops
+    ADD COLUMN IF NOT EXISTS terms_version INT NOT NULL DEFAULT 1;
+
+-- terms revisions are never updated nor deleted, shops.terms is the copy of the latest one
+CREATE TABLE IF NOT EXISTS shop_terms_versions (
+    shop_id UUID NOT NULL,
+    version INT NOT NULL CHECK (version > 0),
+    terms TEXT NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (shop_id, version),
+    FOREIGN KEY (shop_id) REFERENCES shops (id)
+);
+
+-- the current terms of every existing shop become its first version
+INSERT INTO shop_terms_versions (shop_id, version, terms, created_by, created_at)
+SELECT id, 1, terms, user_id, updated_at
+FROM shops
+ON CONFLICT (shop_id, version) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS shop_terms_acceptances (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shop_id UUID NOT NULL,
+    version INT NOT NULL,
+    user_id UUID NOT NULL,
+    reference VARCHAR(100), -- what the terms were accepted for, ex: an order id
+    accepted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    FOREIGN KEY (shop_id, version) REFERENCES shop_terms_versions (shop_id, version)
+);
+
+-- recording the same acceptance again is a no-op
+CREATE UNIQUE INDEX IF NOT EXISTS shop_terms_acceptances_unique_idx ON shop_terms_acceptances (shop_id, version, user_id, COALESCE(reference, ''));
+CREATE INDEX IF NOT EXISTS shop_terms_acceptances_user_id_idx ON shop_terms_acceptances (user_id, accepted_at DESC);
@@ -0,0 +1,2 @@
+-- the backfilled products can not be told apart from the ones deleted on purpose
+SELECT 1;
@@ -0,0 +1,10 @@
+-- products of shops deleted before the deletion cascaded were left behind
+UPDATE products p
+SET
+    deleted_at = s.deleted_at,
+    updated_at = now()
+FROM shops s
+WHERE
+    s.id = p.shop_id
+    AND s.deleted_at IS NOT NULL
+    AND p.deleted_at IS NULL;
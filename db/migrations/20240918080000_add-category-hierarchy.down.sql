@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS categories_move_descendants ON categories;
+DROP TRIGGER IF EXISTS categories_set_path ON categories;
+DROP FUNCTION IF EXISTS category_move_descendants();
+DROP FUNCTION IF EXISTS category_set_path();
+
+DROP INDEX IF EXISTS categories_path_idx;
+DROP INDEX IF EXISTS categories_parent_id_idx;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS depth,
+    DROP COLUMN IF EXISTS path,
+    DROP COLUMN IF EXISTS parent_id;
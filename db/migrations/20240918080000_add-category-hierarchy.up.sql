@@ -0,0 +1,72 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES categories (id),
+    ADD COLUMN IF NOT EXISTS path TEXT,
+    ADD COLUMN IF NOT EXISTS depth INT NOT NULL DEFAULT 0;
+
+-- every existing category becomes a root
+UPDATE categories SET path = '/' || id || '/' WHERE path IS NULL;
+
+ALTER TABLE categories ALTER COLUMN path SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
+CREATE INDEX IF NOT EXISTS categories_path_idx ON categories (path text_pattern_ops);
+
+-- category_set_path derives the materialised path ("/root/.../self/") from the parent
+-- and refuses to move a category under one of its own descendants.
+CREATE OR REPLACE FUNCTION category_set_path()
+RETURNS TRIGGER AS $$
+DECLARE
+    parent_path TEXT;
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        NEW.path := '/' || NEW.id || '/';
+        NEW.depth := 0;
+        RETURN NEW;
+    END IF;
+
+    SELECT path INTO parent_path FROM categories WHERE id = NEW.parent_id;
+    IF parent_path IS NULL THEN
+        RAISE EXCEPTION 'parent category % does not exist', NEW.parent_id
+            USING ERRCODE = 'foreign_key_violation';
+    END IF;
+
+    IF parent_path LIKE '%/' || NEW.id || '/%' THEN
+        RAISE EXCEPTION 'category % cannot be moved under its own descendant', NEW.id
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    NEW.path := parent_path || NEW.id || '/';
+    NEW.depth := array_length(string_to_array(trim(both '/' from NEW.path), '/'), 1) - 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- category_move_descendants rewrites the paths of the subtree after its root has moved.
+CREATE OR REPLACE FUNCTION category_move_descendants()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- the rows touched below fire this trigger again, their subtrees are already rewritten
+    IF pg_trigger_depth() > 1 THEN
+        RETURN NULL;
+    END IF;
+
+    UPDATE categories
+    SET
+        path = NEW.path || substring(path FROM length(OLD.path) + 1),
+        depth = depth + NEW.depth - OLD.depth
+    WHERE
+        path LIKE OLD.path || '%'
+        AND id <> NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories_set_path
+    BEFORE INSERT OR UPDATE OF parent_id ON categories
+    FOR EACH ROW EXECUTE FUNCTION category_set_path();
+
+CREATE TRIGGER categories_move_descendants
+    AFTER UPDATE OF path ON categories
+    FOR EACH ROW
+    WHEN (OLD.path IS DISTINCT FROM NEW.path)
+    EXECUTE FUNCTION category_move_descendants();
@@ -0,0 +1,12 @@
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS sort_order INT NOT NULL DEFAULT 0;
+
+-- keep the current alphabetical order among the siblings
+UPDATE categories c
+SET sort_order = o.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY name) - 1 AS position
+    FROM categories
+) o
+WHERE o.id = c.id;
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_sort_order_idx ON categories (parent_id, sort_order);
@@ -0,0 +1,12 @@
+-- merged categories keep pointing old links to the category that absorbed them
+CREATE TABLE IF NOT EXISTS category_redirects (
+    source_id UUID PRIMARY KEY,
+    target_id UUID NOT NULL,
+    merged_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() NOT NULL,
+
+    FOREIGN KEY (source_id) REFERENCES categories (id),
+    FOREIGN KEY (target_id) REFERENCES categories (id)
+);
+
+CREATE INDEX IF NOT EXISTS category_redirects_target_id_idx ON category_redirects (target_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS categories_slug_key;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS icon_url,
+    DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,36 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS slug VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS icon_url TEXT;
+
+-- slugs come from the names, duplicates get the first free numbered suffix in creation order,
+-- as the application does, so that a name like "HP 2" can not collide with a second "HP"
+DO $$
+DECLARE
+    category RECORD;
+    base TEXT;
+    candidate TEXT;
+    n INT;
+BEGIN
+    FOR category IN
+        SELECT id, name
+        FROM categories
+        WHERE slug IS NULL
+        ORDER BY created_at, id
+    LOOP
+        base := COALESCE(NULLIF(left(trim(both '-' FROM regexp_replace(lower(category.name), '[^a-z0-9]+', '-', 'g')), 90), ''), 'kategori');
+        candidate := base;
+        n := 1;
+
+        WHILE EXISTS (SELECT 1 FROM categories WHERE slug = candidate) LOOP
+            n := n + 1;
+            candidate := base || '-' || n;
+        END LOOP;
+
+        UPDATE categories SET slug = candidate WHERE id = category.id;
+    END LOOP;
+END;
+$$;
+
+ALTER TABLE categories ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS categories_slug_key ON categories (slug) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE shop_transfers DROP COLUMN IF EXISTS keep_previous_owner;
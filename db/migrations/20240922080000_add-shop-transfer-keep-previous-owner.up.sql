@@ -0,0 +1,2 @@
+-- the previous owner leaves the shop on transfer unless one of the parties asks to keep them
+ALTER TABLE shop_transfers ADD COLUMN IF NOT EXISTS keep_previous_owner BOOLEAN NOT NULL DEFAULT FALSE;
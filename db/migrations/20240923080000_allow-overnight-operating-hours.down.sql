@@ -0,0 +1,29 @@
+CREATE OR REPLACE FUNCTION shop_is_open(p_shop_id UUID, p_at TIMESTAMPTZ DEFAULT now())
+RETURNS BOOLEAN AS $$
+    SELECT
+        NOT (
+            s.vacation_mode
+            AND (p_at AT TIME ZONE s.timezone)::date >= COALESCE(s.vacation_start, '-infinity'::date)
+            AND (p_at AT TIME ZONE s.timezone)::date <= COALESCE(s.vacation_end, 'infinity'::date)
+        )
+        AND (
+            NOT EXISTS (SELECT 1 FROM shop_operating_hours h WHERE h.shop_id = s.id)
+            OR EXISTS (
+                SELECT 1
+                FROM shop_operating_hours h
+                WHERE
+                    h.shop_id = s.id
+                    AND h.weekday = EXTRACT(DOW FROM p_at AT TIME ZONE s.timezone)
+                    AND (p_at AT TIME ZONE s.timezone)::time >= h.opens_at
+                    AND (p_at AT TIME ZONE s.timezone)::time < h.closes_at
+            )
+        )
+    FROM shops s
+    WHERE s.id = p_shop_id
+$$ LANGUAGE sql STABLE;
+
+-- overnight hours can not be stored before this migration
+DELETE FROM shop_operating_hours WHERE closes_at < opens_at;
+
+ALTER TABLE shop_operating_hours DROP CONSTRAINT IF EXISTS shop_operating_hours_closes_at_check;
+ALTER TABLE shop_operating_hours ADD CONSTRAINT shop_operating_hours_closes_at_check CHECK (closes_at > opens_at);
@@ -0,0 +1,44 @@
+-- hours closing at or before they open run past midnight, ex: 20:00 - 02:00 closes the next day
+ALTER TABLE shop_operating_hours DROP CONSTRAINT IF EXISTS shop_operating_hours_closes_at_check;
+ALTER TABLE shop_operating_hours ADD CONSTRAINT shop_operating_hours_closes_at_check CHECK (closes_at <> opens_at);
+
+-- shop_is_open tells whether the shop takes orders at the given time, in the shop timezone.
+-- The vacation dates are inclusive, a vacation without start date starts right away.
+-- Overnight hours open on their weekday and go on until closes_at the day after.
+CREATE OR REPLACE FUNCTION shop_is_open(p_shop_id UUID, p_at TIMESTAMPTZ DEFAULT now())
+RETURNS BOOLEAN AS $$
+    SELECT
+        NOT (
+            s.vacation_mode
+            AND (p_at AT TIME ZONE s.timezone)::date >= COALESCE(s.vacation_start, '-infinity'::date)
+            AND (p_at AT TIME ZONE s.timezone)::date <= COALESCE(s.vacation_end, 'infinity'::date)
+        )
+        AND (
+            NOT EXISTS (SELECT 1 FROM shop_operating_hours h WHERE h.shop_id = s.id)
+            OR EXISTS (
+                SELECT 1
+                FROM shop_operating_hours h
+                WHERE
+                    h.shop_id = s.id
+                    AND (
+                        -- the hours of the day
+                        (
+                            h.weekday = EXTRACT(DOW FROM p_at AT TIME ZONE s.timezone)
+                            AND (p_at AT TIME ZONE s.timezone)::time >= h.opens_at
+                            AND (
+                                h.closes_at < h.opens_at
+                                OR (p_at AT TIME ZONE s.timezone)::time < h.closes_at
+                            )
+                        )
+                        -- the overnight hours of the day before
+                        OR (
+                            h.weekday = (EXTRACT(DOW FROM p_at AT TIME ZONE s.timezone) + 6)::int % 7
+                            AND h.closes_at < h.opens_at
+                            AND (p_at AT TIME ZONE s.timezone)::time < h.closes_at
+                        )
+                    )
+            )
+        )
+    FROM shops s
+    WHERE s.id = p_shop_id
+$$ LANGUAGE sql STABLE;
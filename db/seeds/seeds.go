@@ -2,8 +2,10 @@ package seeds
 
 import (
 	"codebase-app/internal/adapter"
+	"codebase-app/pkg"
 	"context"
 	"os"
+	"strings"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/jmoiron/sqlx"
@@ -109,13 +111,14 @@ func (s *Seed) categoriesSeed(total int) {
 		dataUserToInsert["id"] = ulid.Make().String()
 		dataUserToInsert["name"] = name
 		dataUserToInsert["description"] = "deskripsi kategori " + name
+		dataUserToInsert["slug"] = pkg.Slugify(name, 70) + "-" + strings.ToLower(dataUserToInsert["id"].(string))
 
 		userMaps = append(userMaps, dataUserToInsert)
 	}
 
 	_, err = tx.NamedExec(`
-		INSERT INTO categories (name, description)
-		VALUES (:name, :description)
+		INSERT INTO categories (name, description, slug)
+		VALUES (:name, :description, :slug)
 	`, userMaps)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating categories")
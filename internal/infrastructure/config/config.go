@@ -2,6 +2,7 @@ package config
 
 import (
 	"codebase-app/pkg/config"
+	"fmt"
 	"sync"
 
 	"github.com/rs/zerolog/log"
@@ -14,16 +15,19 @@ var (
 
 type Config struct {
 	App struct {
-		Name                    string `env:"APP_NAME"`
-		Environtment            string `env:"APP_ENV" env-default:"production"`
-		BaseURL                 string `env:"APP_BASE_URL" env-default:"http://localhost:3000"`
-		Port                    string `env:"APP_PORT"`
-		WSPort                  string `env:"WS_PORT"`
-		LogLevel                string `env:"APP_LOG_LEVEL" env-default:"debug"`
-		LogFile                 string `env:"APP_LOG_FILE" env-default:"./logs/app.log"`
-		LogFileWs               string `env:"APP_LOG_FILE_WS" env-default:"./logs/ws.log"`
-		LocalStoragePublicPath  string `env:"LOCAL_STORAGE_PUBLIC_PATH" env-default:"./storage/public"`
-		LocalStoragePrivatePath string `env:"LOCAL_STORAGE_PRIVATE_PATH" env-default:"./storage/private"`
+		Name                    string   `env:"APP_NAME"`
+		Environtment            string   `env:"APP_ENV" env-default:"production"`
+		BaseURL                 string   `env:"APP_BASE_URL" env-default:"http://localhost:3000"`
+		Port                    string   `env:"APP_PORT"`
+		WSPort                  string   `env:"WS_PORT"`
+		LogLevel                string   `env:"APP_LOG_LEVEL" env-default:"debug"`
+		LogFile                 string   `env:"APP_LOG_FILE" env-default:"./logs/app.log"`
+		LogFileWs               string   `env:"APP_LOG_FILE_WS" env-default:"./logs/ws.log"`
+		LocalStoragePublicPath  string   `env:"LOCAL_STORAGE_PUBLIC_PATH" env-default:"./storage/public"`
+		LocalStoragePrivatePath string   `env:"LOCAL_STORAGE_PRIVATE_PATH" env-default:"./storage/private"`
+		StorageDriver           string   `env:"STORAGE_DRIVER" env-default:"local" env-description:"where uploads are stored: local or spaces"`
+		DefaultLocale           string   `env:"APP_DEFAULT_LOCALE" env-default:"id"`
+		SupportedLocales        []string `env:"APP_SUPPORTED_LOCALES" env-default:"id,en"`
 	}
 	DB struct {
 		ConnectionTimeout int `env:"DB_CONN_TIMEOUT" env-default:"30" env-description:"database timeout in seconds"`
@@ -31,10 +35,33 @@ type Config struct {
 		MaxIdleCons       int `env:"DB_MAX_IdLE_CONS" env-default:"20" env-description:"database max idle conn in seconds"`
 		ConnMaxLifetime   int `env:"DB_CONN_MAX_LIFETIME" env-default:"0" env-description:"database conn max lifetime in seconds"`
 	}
+	Product struct {
+		ViewFlushInterval         int `env:"PRODUCT_VIEW_FLUSH_INTERVAL" env-default:"30" env-description:"product view buffer flush interval in seconds"`
+		PopularityRefreshInterval int `env:"PRODUCT_POPULARITY_REFRESH_INTERVAL" env-default:"3600" env-description:"popularity score refresh interval in seconds"`
+		RelatedCacheTTL           int `env:"PRODUCT_RELATED_CACHE_TTL" env-default:"600" env-description:"related products cache lifetime in seconds"`
+	}
+	Shop struct {
+		VacationCheckInterval int `env:"SHOP_VACATION_CHECK_INTERVAL" env-default:"300" env-description:"interval in seconds between checks for ended vacations"`
+		StatsRefreshInterval  int `env:"SHOP_STATS_REFRESH_INTERVAL" env-default:"900" env-description:"interval in seconds between refreshes of the shop dashboard statistics"`
+		TransferExpiry        int `env:"SHOP_TRANSFER_EXPIRY" env-default:"72" env-description:"hours a shop ownership transfer stays open for the recipient"`
+	}
 	Guard struct {
 		JwtPrivateKey   string `env:"JWT_PRIVATE_KEY"`
 		JwtPrivateKeyWs string `env:"JWT_PRIVATE_KEY_WS"`
 		JwtWsExp        int    `env:"JWT_WS_EXP" env-default:"10"` // 10 seconds
+
+		JwtAlgorithm      string   `env:"JWT_ALGORITHM" env-default:"HS256" env-description:"HS256 with JWT_PRIVATE_KEY, or RS256 or ES256 with PEM keys"`
+		JwtSigningKeyFile string   `env:"JWT_SIGNING_KEY_FILE" env-description:"PEM private key the tokens are signed with, RS256 and ES256 only"`
+		JwtSigningKeyId   string   `env:"JWT_SIGNING_KEY_ID" env-description:"kid of the signing key, defaults to its JWK thumbprint"`
+		JwtVerifyKeyFiles []string `env:"JWT_VERIFY_KEY_FILES" env-description:"comma separated kid=path PEM public keys still accepted, ex: the previous signing key, a bare path uses the JWK thumbprint as kid"`
+		JwksUrl           string   `env:"JWT_JWKS_URL" env-description:"JWKS endpoint consulted for the kids not known locally"`
+		JwksCacheTTL      int      `env:"JWT_JWKS_CACHE_TTL" env-default:"300" env-description:"seconds the keys fetched from JWT_JWKS_URL are cached"`
+	}
+	Gateway struct {
+		TrustMode  string `env:"GATEWAY_TRUST_MODE" env-default:"hmac" env-description:"how X-USER-ID is trusted: hmac, jwt, or none in development only"`
+		HmacSecret string `env:"GATEWAY_HMAC_SECRET" env-description:"secret shared with the gateway in hmac mode"`
+		JwtSecret  string `env:"GATEWAY_JWT_SECRET" env-description:"secret of the gateway tokens in jwt mode"`
+		MaxSkew    int    `env:"GATEWAY_MAX_SKEW" env-default:"60" env-description:"seconds a gateway signature stays fresh"`
 	}
 	ShopeefunPostgres struct {
 		Host     string `env:"SHOPEEFUN_POSTGRES_HOST" env-default:"localhost"`
@@ -93,9 +120,30 @@ func (c *Configure) Initialize() {
 		}); err != nil {
 			log.Fatal().Err(err).Msg("get config error")
 		}
+
+		if err := Envs.checkIntervals(); err != nil {
+			log.Fatal().Err(err).Msg("invalid config")
+		}
 	})
 }
 
+// checkIntervals rejects the background job intervals that are not positive,
+// a ticker can not run every zero or less seconds.
+func (c *Config) checkIntervals() error {
+	for env, seconds := range map[string]int{
+		"PRODUCT_VIEW_FLUSH_INTERVAL":         c.Product.ViewFlushInterval,
+		"PRODUCT_POPULARITY_REFRESH_INTERVAL": c.Product.PopularityRefreshInterval,
+		"SHOP_VACATION_CHECK_INTERVAL":        c.Shop.VacationCheckInterval,
+		"SHOP_STATS_REFRESH_INTERVAL":         c.Shop.StatsRefreshInterval,
+	} {
+		if seconds <= 0 {
+			return fmt.Errorf("config: %s must be a positive number of seconds, got %d", env, seconds)
+		}
+	}
+
+	return nil
+}
+
 // WithPath will assign to field path Configure.
 func WithPath(path string) Option {
 	return func(c *Configure) error {
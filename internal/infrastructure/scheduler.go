@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunEvery calls fn every interval in a background goroutine.
+//
+// The returned stop function cancels the loop and waits for the last run to finish,
+// so it is safe to call from a shutdown hook before the database is closed.
+func RunEvery(name string, interval time.Duration, fn func(ctx context.Context) error) (stop func()) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		done        = make(chan struct{})
+		ticker      = time.NewTicker(interval)
+	)
+
+	go func() {
+		defer close(done)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := fn(ctx); err != nil {
+					log.Error().Err(err).Str("job", name).Msg("infrastructure::RunEvery - Job failed")
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
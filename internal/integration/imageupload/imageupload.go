@@ -0,0 +1,134 @@
+package integration
+
+import (
+	"bytes"
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure/config"
+	dospace "codebase-app/internal/integration/digitaloceanspace"
+	dospaceEntity "codebase-app/internal/integration/digitaloceanspace/entity"
+	localstorage "codebase-app/internal/integration/localstorage"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Rules restricts the images accepted by an upload, zero values are not checked.
+type Rules struct {
+	MaxSize   int64
+	MinWidth  int
+	MinHeight int
+	MaxWidth  int
+	MaxHeight int
+}
+
+type ImageUploadContract interface {
+	// Upload validates the image and stores it under dir, returning its public URL.
+	Upload(ctx context.Context, file *multipart.FileHeader, dir string, rules Rules) (url string, err error)
+}
+
+type imageupload struct {
+	dospace      dospace.DigitaloceanSpaceContract
+	localstorage localstorage.LocalStorageContract
+}
+
+// NewImageUploadIntegration stores the images in DigitalOcean Spaces when the storage adapter
+// is connected, and in the local public storage otherwise.
+func NewImageUploadIntegration() ImageUploadContract {
+	u := &imageupload{
+		localstorage: localstorage.NewLocalStorageIntegration(),
+	}
+
+	if adapter.Adapters.ShopeefunStorage != nil {
+		u.dospace = dospace.NewDigitalOceanSpaceIntegration()
+	}
+
+	return u
+}
+
+func (u *imageupload) Upload(ctx context.Context, file *multipart.FileHeader, dir string, rules Rules) (string, error) {
+	if file == nil {
+		return "", errmsg.NewCustomErrors(400, errmsg.WithErrors("file", "file harus diisi."))
+	}
+
+	if rules.MaxSize > 0 && file.Size > rules.MaxSize {
+		return "", errmsg.NewCustomErrors(400, errmsg.WithErrors("file",
+			fmt.Sprintf("ukuran file maksimal %d KB.", rules.MaxSize/1024),
+		))
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		log.Error().Err(err).Str("filename", file.Filename).Msg("integration::imageupload-Upload Error while opening file")
+		return "", err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		log.Error().Err(err).Str("filename", file.Filename).Msg("integration::imageupload-Upload Error while reading file")
+		return "", err
+	}
+
+	if err := checkImage(content, rules); err != nil {
+		return "", err
+	}
+
+	if u.dospace != nil {
+		res, err := u.dospace.UploadFile(ctx, &dospaceEntity.UploadFileRequest{File: file})
+		if err != nil {
+			return "", err
+		}
+
+		return res.Url, nil
+	}
+
+	fullpath, err := u.localstorage.Save(
+		base64.StdEncoding.EncodeToString(content),
+		filepath.Join(config.Envs.App.LocalStoragePublicPath, dir),
+	)
+	if err != nil {
+		log.Error().Err(err).Str("filename", file.Filename).Msg("integration::imageupload-Upload Error while saving file")
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/storage/public/%s/%s", config.Envs.App.BaseURL, dir, filepath.Base(fullpath)), nil
+}
+
+// checkImage accepts JPEG and PNG images within the dimensions of the rules.
+// The content is sniffed rather than trusting the extension or the Content-Type sent by the client.
+func checkImage(content []byte, rules Rules) error {
+	switch http.DetectContentType(content) {
+	case "image/jpeg", "image/png":
+	default:
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("file", "file harus berupa gambar JPEG atau PNG."))
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("file", "gambar tidak dapat dibaca."))
+	}
+
+	if (rules.MinWidth > 0 && cfg.Width < rules.MinWidth) || (rules.MinHeight > 0 && cfg.Height < rules.MinHeight) {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("file",
+			fmt.Sprintf("ukuran gambar minimal %dx%d piksel.", rules.MinWidth, rules.MinHeight),
+		))
+	}
+
+	if (rules.MaxWidth > 0 && cfg.Width > rules.MaxWidth) || (rules.MaxHeight > 0 && cfg.Height > rules.MaxHeight) {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("file",
+			fmt.Sprintf("ukuran gambar maksimal %dx%d piksel.", rules.MaxWidth, rules.MaxHeight),
+		))
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/pkg/locale"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale picks the content locale from the lang query parameter, then the Accept-Language header,
+// and falls back to the default locale when neither is supported.
+func Locale(c *fiber.Ctx) error {
+	var (
+		supported = config.Envs.App.SupportedLocales
+		lang      = strings.ToLower(c.Query("lang"))
+	)
+
+	if !locale.IsSupported(lang, supported) {
+		lang = config.Envs.App.DefaultLocale
+		if negotiated, ok := locale.Negotiate(c.Get(fiber.HeaderAcceptLanguage), supported); ok {
+			lang = negotiated
+		}
+	}
+
+	c.Locals("locale", lang)
+
+	return c.Next()
+}
+
+// GetLocale returns the locale picked by the Locale middleware, or the default locale.
+func GetLocale(c *fiber.Ctx) string {
+	if locale, ok := c.Locals("locale").(string); ok {
+		return locale
+	}
+
+	return config.Envs.App.DefaultLocale
+}
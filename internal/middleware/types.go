@@ -8,6 +8,7 @@ import (
 type Locals struct {
 	UserId string
 	Role   string
+	Locale string
 }
 
 func GetLocals(c *fiber.Ctx) *Locals {
@@ -19,6 +20,8 @@ func GetLocals(c *fiber.Ctx) *Locals {
 		log.Warn().Msg("middleware::Locals-GetLocals failed to get user_id from locals")
 	}
 
+	l.Locale = GetLocale(c)
+
 	return &l
 }
 
@@ -29,3 +32,7 @@ func (l *Locals) GetUserId() string {
 func (l *Locals) GetRole() string {
 	return l.Role
 }
+
+func (l *Locals) GetLocale() string {
+	return l.Locale
+}
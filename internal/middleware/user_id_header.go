@@ -1,23 +1,129 @@
 package middleware
 
 import (
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/pkg/gatewayauth"
+	"codebase-app/pkg/jwthandler"
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 )
 
+var (
+	errUnknownTrustMode     = errors.New("middleware: unknown gateway trust mode")
+	errMissingGatewaySecret = errors.New("middleware: gateway secret not configured")
+	errUntrustedGateway     = errors.New("middleware: gateway trust mode none is only allowed in development")
+)
+
+// CheckGatewayTrust validates the GATEWAY_* settings, the server refuses to start when they
+// would let requests through unverified: an unknown mode, a missing secret, or the none mode
+// outside a development environment.
+func CheckGatewayTrust() error {
+	gateway := config.Envs.Gateway
+
+	switch strings.ToLower(gateway.TrustMode) {
+	case "none":
+		if config.Envs.App.Environtment != "development" {
+			return errUntrustedGateway
+		}
+	case "hmac":
+		if gateway.HmacSecret == "" {
+			return errMissingGatewaySecret
+		}
+	case "jwt":
+		if gateway.JwtSecret == "" {
+			return errMissingGatewaySecret
+		}
+	default:
+		return errUnknownTrustMode
+	}
+
+	return nil
+}
+
 func UserIdHeader(c *fiber.Ctx) error {
-	userId := c.Get("X-USER-ID")
 	unauthorizedResponse := fiber.Map{
 		"message": "Unauthorized",
 		"success": false,
 	}
 
+	userId, role, err := gatewayIdentity(c)
+	if err != nil {
+		log.Error().Err(err).Str("mode", config.Envs.Gateway.TrustMode).Msg("middleware::UserIdHeader - Unauthorized [Invalid gateway identity]")
+		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
+	}
+
 	if userId == "" {
 		log.Error().Msg("middleware::UserIdHeader - Unauthorized [Header not set]")
 		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
 	}
 
 	c.Locals("user_id", userId)
+	c.Locals("role", role)
 
 	return c.Next()
 }
+
+// OptionalUserIdHeader stores the X-USER-ID header when it is sent, so public routes
+// can show more to a known user. The user id local is empty for anonymous requests,
+// an identity the gateway failed to vouch for is still rejected.
+func OptionalUserIdHeader(c *fiber.Ctx) error {
+	if c.Get("X-USER-ID") == "" && c.Get("X-GATEWAY-TOKEN") == "" {
+		c.Locals("user_id", "")
+		return c.Next()
+	}
+
+	return UserIdHeader(c)
+}
+
+// gatewayIdentity returns the user id and role forwarded by the gateway, verified according
+// to GATEWAY_TRUST_MODE:
+//   - none: X-USER-ID is trusted as is and no role is taken, an explicit opt-in for development.
+//   - hmac: X-GATEWAY-SIGNATURE signs X-USER-ID, X-USER-ROLE and X-GATEWAY-TIMESTAMP, see gatewayauth.
+//   - jwt: X-GATEWAY-TOKEN is a token from jwthandler.GenerateGatewayToken.
+func gatewayIdentity(c *fiber.Ctx) (userId, role string, err error) {
+	switch strings.ToLower(config.Envs.Gateway.TrustMode) {
+	case "none":
+		if config.Envs.App.Environtment != "development" {
+			return "", "", errUntrustedGateway
+		}
+
+		return c.Get("X-USER-ID"), "", nil
+	case "hmac":
+		if config.Envs.Gateway.HmacSecret == "" {
+			return "", "", errMissingGatewaySecret
+		}
+
+		userId, role = c.Get("X-USER-ID"), c.Get("X-USER-ROLE")
+		err = gatewayauth.Verify(
+			[]byte(config.Envs.Gateway.HmacSecret),
+			userId,
+			role,
+			c.Get("X-GATEWAY-TIMESTAMP"),
+			c.Get("X-GATEWAY-SIGNATURE"),
+			time.Second*time.Duration(config.Envs.Gateway.MaxSkew),
+			time.Now(),
+		)
+		if err != nil {
+			return "", "", err
+		}
+
+		return userId, role, nil
+	case "jwt":
+		if config.Envs.Gateway.JwtSecret == "" {
+			return "", "", errMissingGatewaySecret
+		}
+
+		claims, err := jwthandler.ParseGatewayToken(c.Get("X-GATEWAY-TOKEN"))
+		if err != nil {
+			return "", "", err
+		}
+
+		return claims.UserId, claims.Role, nil
+	default:
+		return "", "", errUnknownTrustMode
+	}
+}
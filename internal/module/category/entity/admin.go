@@ -0,0 +1,91 @@
+package entity
+
+import "mime/multipart"
+
+type CreateCategoryRequest struct {
+	// ParentId is empty for a root category.
+	ParentId    *string `json:"parent_id" validate:"omitempty,uuid" db:"parent_id"`
+	Name        string  `json:"name" validate:"required,max=255" db:"name"`
+	Description string  `json:"description" validate:"required,max=255" db:"description"`
+
+	// Slug is generated from the name when omitted.
+	Slug *string `json:"slug" validate:"omitempty,max=100,slug" db:"slug"`
+
+	// SortOrder places the category among its siblings, it goes last when omitted.
+	SortOrder *int `json:"sort_order" validate:"omitempty,gte=0" db:"sort_order"`
+}
+
+type CreateCategoryResponse struct {
+	Id   string `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
+}
+
+type UpdateCategoryRequest struct {
+	Id string `validate:"uuid" db:"id"`
+
+	// ParentId moves the category with its subtree, Root moves it to the top level instead.
+	// The category stays where it is when both are omitted.
+	ParentId *string `json:"parent_id" validate:"omitempty,uuid" db:"parent_id"`
+	Root     bool    `json:"root" db:"-"`
+
+	// Name and Description are left unchanged when omitted.
+	Name        *string `json:"name" validate:"omitnil,min=1,max=255" db:"name"`
+	Description *string `json:"description" validate:"omitnil,min=1,max=255" db:"description"`
+
+	// Slug is left unchanged when omitted, renaming a category keeps its links.
+	Slug *string `json:"slug" validate:"omitempty,max=100,slug" db:"slug"`
+}
+
+type UpdateCategoryResponse struct {
+	Id   string `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
+}
+
+type DeleteCategoryRequest struct {
+	Id string `validate:"uuid" db:"id"`
+
+	// MoveTo receives the products of the category, the deletion is refused
+	// while the category still has products and no target is given.
+	MoveTo string `query:"move_to" validate:"omitempty,uuid" db:"move_to"`
+}
+
+type DeleteCategoryResponse struct {
+	MovedProducts int `json:"moved_products"`
+}
+
+type ReorderCategoriesRequest struct {
+	// ParentId selects the siblings to reorder, empty for the roots.
+	ParentId *string `json:"parent_id" validate:"omitempty,uuid"`
+
+	// CategoryIds lists every sibling in the new order.
+	CategoryIds []string `json:"category_ids" validate:"required,min=1,unique_in_slice,dive,uuid"`
+}
+
+type MergeCategoryRequest struct {
+	SourceId string `validate:"uuid"`
+	ActorId  string `validate:"omitempty,uuid"`
+
+	// TargetId absorbs the products and subcategories of the source.
+	TargetId string `json:"target_id" validate:"uuid"`
+}
+
+type MergeCategoryResponse struct {
+	TargetId        string `json:"target_id"`
+	MovedProducts   int    `json:"moved_products"`
+	MovedCategories int    `json:"moved_categories"`
+}
+
+type UploadCategoryIconRequest struct {
+	Id string `validate:"uuid" db:"id"`
+
+	File *multipart.FileHeader `form:"file" validate:"required"`
+}
+
+type UpdateCategoryIconRequest struct {
+	Id  string `validate:"uuid" db:"id"`
+	Url string `validate:"required,url" db:"icon_url"`
+}
+
+type UpdateCategoryIconResponse struct {
+	Url string `json:"url"`
+}
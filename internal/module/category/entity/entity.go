@@ -3,8 +3,14 @@ package entity
 import "codebase-app/pkg/types"
 
 type CategoryItem struct {
-	Id   string `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	Id       string  `json:"id" db:"id"`
+	ParentId *string `json:"parent_id" db:"parent_id"`
+	Name     string  `json:"name" db:"name"`
+	Slug     string  `json:"slug" db:"slug"`
+	IconUrl  *string `json:"icon_url" db:"icon_url"`
+
+	// ProductCount counts the active products of the category and of its subcategories.
+	ProductCount int `json:"product_count" db:"product_count"`
 }
 
 type CategoriesResponse struct {
@@ -15,6 +21,8 @@ type CategoriesResponse struct {
 type CategoriesRequest struct {
 	Page     int `query:"page" validate:"required"`
 	Paginate int `query:"paginate" validate:"required"`
+
+	Locale string `query:"-"`
 }
 
 func (r *CategoriesRequest) SetDefault() {
@@ -26,3 +34,54 @@ func (r *CategoriesRequest) SetDefault() {
 		r.Paginate = 10
 	}
 }
+
+type GetCategoryRequest struct {
+	// IdOrSlug also accepts the id or slug of a category merged into another one.
+	IdOrSlug string `validate:"required,max=100"`
+	Locale   string `query:"-"`
+}
+
+type GetCategoryResponse struct {
+	CategoryItem
+	Description string `json:"description" db:"description"`
+}
+
+type CategoryTreeRequest struct {
+	Locale string `query:"-"`
+}
+
+// CategoryNode is a category with its subcategories nested below it.
+type CategoryNode struct {
+	Id       string         `json:"id" db:"id"`
+	ParentId *string        `json:"parent_id" db:"parent_id"`
+	Name     string         `json:"name" db:"name"`
+	Slug     string         `json:"slug" db:"slug"`
+	IconUrl  *string        `json:"icon_url" db:"icon_url"`
+	Children []CategoryNode `json:"children" db:"-"`
+}
+
+type CategoryTreeResponse struct {
+	Items []CategoryNode `json:"items"`
+}
+
+type CategoryChildrenRequest struct {
+	Id     string `validate:"uuid"`
+	Locale string `query:"-"`
+}
+
+type CategoryChildrenResponse struct {
+	Items []CategoryItem `json:"items"`
+}
+
+type UpsertCategoryTranslationRequest struct {
+	CategoryId string `validate:"uuid" db:"category_id"`
+	Locale     string `params:"locale" validate:"required,bcp47_language_tag" db:"locale"`
+
+	Name        string `json:"name" validate:"required,max=255" db:"name"`
+	Description string `json:"description" validate:"required,max=255" db:"description"`
+}
+
+type DeleteCategoryTranslationRequest struct {
+	CategoryId string `validate:"uuid" db:"category_id"`
+	Locale     string `params:"locale" validate:"required,bcp47_language_tag" db:"locale"`
+}
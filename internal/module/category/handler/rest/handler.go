@@ -2,19 +2,23 @@ package handler
 
 import (
 	"codebase-app/internal/adapter"
+	imageupload "codebase-app/internal/integration/imageupload"
+	"codebase-app/internal/middleware"
 	"codebase-app/internal/module/category/entity"
 	"codebase-app/internal/module/category/ports"
 	"codebase-app/internal/module/category/repository"
 	"codebase-app/internal/module/category/service"
 	"codebase-app/pkg/errmsg"
 	"codebase-app/pkg/response"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 )
 
 type categoryHandler struct {
-	service ports.CategoryService
+	service  ports.CategoryService
+	uploader imageupload.ImageUploadContract
 }
 
 func NewCategoryHandler() *categoryHandler {
@@ -24,12 +28,28 @@ func NewCategoryHandler() *categoryHandler {
 		service = service.NewCategoryService(repo)
 	)
 	handler.service = service
+	handler.uploader = imageupload.NewImageUploadIntegration()
 
 	return handler
 }
 
 func (h *categoryHandler) Register(router fiber.Router) {
+	var (
+		admin = []fiber.Handler{middleware.AuthBearer, middleware.AuthRole([]string{"admin"})}
+	)
+
 	router.Get("/categories", h.GetCategories)
+	router.Get("/categories/tree", h.GetCategoryTree)
+	router.Get("/categories/:id_or_slug", h.GetCategory)
+	router.Get("/categories/:id/children", h.GetCategoryChildren)
+	router.Post("/categories", append(admin, h.CreateCategory)...)
+	router.Put("/categories/order", append(admin, h.ReorderCategories)...)
+	router.Patch("/categories/:id", append(admin, h.UpdateCategory)...)
+	router.Delete("/categories/:id", append(admin, h.DeleteCategory)...)
+	router.Post("/categories/:id/merge", append(admin, h.MergeCategory)...)
+	router.Post("/categories/:id/icon", append(admin, h.UploadCategoryIcon)...)
+	router.Put("/categories/:id/translations/:locale", append(admin, h.UpsertCategoryTranslation)...)
+	router.Delete("/categories/:id/translations/:locale", append(admin, h.DeleteCategoryTranslation)...)
 }
 
 func (h *categoryHandler) GetCategories(c *fiber.Ctx) error {
@@ -44,6 +64,7 @@ func (h *categoryHandler) GetCategories(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
+	req.Locale = middleware.GetLocale(c)
 	req.SetDefault()
 
 	if err := v.Validate(req); err != nil {
@@ -61,3 +82,125 @@ func (h *categoryHandler) GetCategories(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 
 }
+
+func (h *categoryHandler) GetCategory(c *fiber.Ctx) error {
+	var (
+		req = new(entity.GetCategoryRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	req.IdOrSlug = strings.ToLower(c.Params("id_or_slug"))
+	req.Locale = middleware.GetLocale(c)
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCategory - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCategory(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) GetCategoryTree(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CategoryTreeRequest)
+		ctx = c.Context()
+	)
+
+	req.Locale = middleware.GetLocale(c)
+
+	resp, err := h.service.GetCategoryTree(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) GetCategoryChildren(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CategoryChildrenRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	req.Id = c.Params("id")
+	req.Locale = middleware.GetLocale(c)
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetCategoryChildren - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetCategoryChildren(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *categoryHandler) UpsertCategoryTranslation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpsertCategoryTranslationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpsertCategoryTranslation - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.CategoryId = c.Params("id")
+	req.Locale = strings.ToLower(c.Params("locale"))
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpsertCategoryTranslation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.UpsertCategoryTranslation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *categoryHandler) DeleteCategoryTranslation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteCategoryTranslationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	req.CategoryId = c.Params("id")
+	req.Locale = strings.ToLower(c.Params("locale"))
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteCategoryTranslation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.DeleteCategoryTranslation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
@@ -7,8 +7,30 @@ import (
 
 type CategoryRepository interface {
 	GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error)
+	GetCategory(ctx context.Context, req *entity.GetCategoryRequest) (*entity.GetCategoryResponse, error)
+	GetCategoryTree(ctx context.Context, req *entity.CategoryTreeRequest) ([]entity.CategoryNode, error)
+	GetCategoryChildren(ctx context.Context, req *entity.CategoryChildrenRequest) (*entity.CategoryChildrenResponse, error)
+	CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CreateCategoryResponse, error)
+	UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.UpdateCategoryResponse, error)
+	DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) (*entity.DeleteCategoryResponse, error)
+	ReorderCategories(ctx context.Context, req *entity.ReorderCategoriesRequest) error
+	MergeCategory(ctx context.Context, req *entity.MergeCategoryRequest) (*entity.MergeCategoryResponse, error)
+	UpdateCategoryIcon(ctx context.Context, req *entity.UpdateCategoryIconRequest) (*entity.UpdateCategoryIconResponse, error)
+	UpsertCategoryTranslation(ctx context.Context, req *entity.UpsertCategoryTranslationRequest) error
+	DeleteCategoryTranslation(ctx context.Context, req *entity.DeleteCategoryTranslationRequest) error
 }
 
 type CategoryService interface {
 	GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error)
+	GetCategory(ctx context.Context, req *entity.GetCategoryRequest) (*entity.GetCategoryResponse, error)
+	GetCategoryTree(ctx context.Context, req *entity.CategoryTreeRequest) (*entity.CategoryTreeResponse, error)
+	GetCategoryChildren(ctx context.Context, req *entity.CategoryChildrenRequest) (*entity.CategoryChildrenResponse, error)
+	CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CreateCategoryResponse, error)
+	UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.UpdateCategoryResponse, error)
+	DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) (*entity.DeleteCategoryResponse, error)
+	ReorderCategories(ctx context.Context, req *entity.ReorderCategoriesRequest) error
+	MergeCategory(ctx context.Context, req *entity.MergeCategoryRequest) (*entity.MergeCategoryResponse, error)
+	UpdateCategoryIcon(ctx context.Context, req *entity.UpdateCategoryIconRequest) (*entity.UpdateCategoryIconResponse, error)
+	UpsertCategoryTranslation(ctx context.Context, req *entity.UpsertCategoryTranslationRequest) error
+	DeleteCategoryTranslation(ctx context.Context, req *entity.DeleteCategoryTranslationRequest) error
 }
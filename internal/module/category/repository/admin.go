@@ -0,0 +1,434 @@
+package repository
+
+import (
+	"codebase-app/internal/module/category/entity"
+	"codebase-app/pkg"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// nextSortOrderQuery puts a category after its last sibling, the parent is the only argument.
+const nextSortOrderQuery = `
+	SELECT COALESCE(MAX(sort_order) + 1, 0)
+	FROM categories
+	WHERE
+		parent_id IS NOT DISTINCT FROM ?::uuid
+		AND deleted_at IS NULL
+`
+
+func (r *categoryRepository) CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CreateCategoryResponse, error) {
+	var resp = new(entity.CreateCategoryResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateCategory - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if req.ParentId != nil {
+		if _, err = r.lockReference(ctx, tx, *req.ParentId, "parent_id"); err != nil {
+			return nil, err
+		}
+	}
+
+	var sortOrder int
+	if req.SortOrder != nil {
+		sortOrder = *req.SortOrder
+
+		query := `
+			UPDATE categories
+			SET sort_order = sort_order + 1
+			WHERE
+				parent_id IS NOT DISTINCT FROM ?::uuid
+				AND deleted_at IS NULL
+				AND sort_order >= ?
+		`
+
+		if _, err = tx.ExecContext(ctx, r.db.Rebind(query), req.ParentId, sortOrder); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::CreateCategory - Failed to shift siblings")
+			return nil, err
+		}
+	} else {
+		if err = tx.GetContext(ctx, &sortOrder, r.db.Rebind(nextSortOrderQuery), req.ParentId); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::CreateCategory - Failed to get sort order")
+			return nil, err
+		}
+	}
+
+	slug, err := r.categorySlug(ctx, tx, req.Slug, req.Name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO categories (parent_id, name, description, slug, sort_order)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING id, slug
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.ParentId,
+		req.Name,
+		req.Description,
+		slug,
+		sortOrder,
+	).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateCategory - Failed to create category")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateCategory - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.UpdateCategoryResponse, error) {
+	var resp = new(entity.UpdateCategoryResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = r.lockCategory(ctx, tx, req.Id); err != nil {
+		return nil, err
+	}
+
+	if req.ParentId != nil {
+		parentPath, err := r.lockReference(ctx, tx, *req.ParentId, "parent_id")
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.Contains(parentPath, "/"+req.Id+"/") {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("parent_id", "Kategori tidak dapat dipindahkan ke dalam dirinya sendiri atau subkategorinya"))
+		}
+	}
+
+	if req.Slug != nil {
+		if _, err = r.categorySlug(ctx, tx, req.Slug, "", req.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	// the parent only changes on request, a category moved to another parent goes after its new siblings
+	move := req.Root || req.ParentId != nil
+	query := `
+		UPDATE categories
+		SET
+			name = COALESCE(?, name),
+			description = COALESCE(?, description),
+			slug = COALESCE(?, slug),
+			sort_order = CASE
+				WHEN ?::boolean AND parent_id IS DISTINCT FROM ?::uuid THEN (` + nextSortOrderQuery + `)
+				ELSE sort_order
+			END,
+			parent_id = CASE WHEN ?::boolean THEN ?::uuid ELSE parent_id END,
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+		RETURNING id, slug
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Name,
+		req.Description,
+		req.Slug,
+		move, req.ParentId,
+		req.ParentId,
+		move, req.ParentId,
+		req.Id,
+	).StructScan(resp)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to update category")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategory - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) (*entity.DeleteCategoryResponse, error) {
+	var resp = new(entity.DeleteCategoryResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = r.lockCategory(ctx, tx, req.Id); err != nil {
+		return nil, err
+	}
+
+	var hasChildren bool
+	err = tx.GetContext(ctx, &hasChildren, r.db.Rebind(`SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = ? AND deleted_at IS NULL)`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to check subcategories")
+		return nil, err
+	}
+
+	if hasChildren {
+		return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Kategori masih memiliki subkategori"))
+	}
+
+	if req.MoveTo != "" {
+		// moving the products onto the deleted category would leave them in a deleted category
+		if strings.EqualFold(req.MoveTo, req.Id) {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("move_to", "Kategori tujuan harus berbeda dengan kategori yang dihapus"))
+		}
+
+		if _, err = r.lockReference(ctx, tx, req.MoveTo, "move_to"); err != nil {
+			return nil, err
+		}
+
+		query := `
+			UPDATE products
+			SET
+				category_id = ?,
+				updated_at = NOW()
+			WHERE
+				deleted_at IS NULL
+				AND category_id = ?
+		`
+
+		result, err := tx.ExecContext(ctx, r.db.Rebind(query), req.MoveTo, req.Id)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to move products")
+			return nil, err
+		}
+
+		moved, _ := result.RowsAffected()
+		resp.MovedProducts = int(moved)
+	} else {
+		var hasProducts bool
+		err = tx.GetContext(ctx, &hasProducts, r.db.Rebind(`SELECT EXISTS (SELECT 1 FROM products WHERE category_id = ? AND deleted_at IS NULL)`), req.Id)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to check products")
+			return nil, err
+		}
+
+		if hasProducts {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Kategori masih memiliki produk, pilih kategori tujuan untuk memindahkan produk"))
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(`UPDATE categories SET deleted_at = NOW(), updated_at = NOW() WHERE id = ?`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to delete category")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategory - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) ReorderCategories(ctx context.Context, req *entity.ReorderCategoriesRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReorderCategories - Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	var siblings []string
+	query := `
+		SELECT id
+		FROM categories
+		WHERE
+			parent_id IS NOT DISTINCT FROM ?::uuid
+			AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	if err = tx.SelectContext(ctx, &siblings, r.db.Rebind(query), req.ParentId); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReorderCategories - Failed to get categories")
+		return err
+	}
+
+	if !sameIds(siblings, req.CategoryIds) {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("category_ids", "Urutan harus memuat semua kategori dengan induk yang sama"))
+	}
+
+	query = `
+		UPDATE categories c
+		SET
+			sort_order = o.position - 1,
+			updated_at = NOW()
+		FROM unnest(?::uuid[]) WITH ORDINALITY AS o(id, position)
+		WHERE c.id = o.id
+	`
+
+	if _, err = tx.ExecContext(ctx, r.db.Rebind(query), pq.Array(req.CategoryIds)); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReorderCategories - Failed to reorder categories")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::ReorderCategories - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) UpdateCategoryIcon(ctx context.Context, req *entity.UpdateCategoryIconRequest) (*entity.UpdateCategoryIconResponse, error) {
+	query := `
+		UPDATE categories
+		SET
+			icon_url = ?,
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Url, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateCategoryIcon - Failed to update category icon")
+		return nil, err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Kategori tidak ditemukan"))
+	}
+
+	return &entity.UpdateCategoryIconResponse{Url: req.Url}, nil
+}
+
+// categorySlug checks that the requested slug is free among the live categories other than
+// excludeId. Without a requested slug one is made from the name, numbered when already taken.
+func (r *categoryRepository) categorySlug(ctx context.Context, tx *sqlx.Tx, slug *string, name, excludeId string) (string, error) {
+	if slug != nil {
+		var taken bool
+
+		query := `
+			SELECT EXISTS (
+				SELECT 1
+				FROM categories
+				WHERE
+					deleted_at IS NULL
+					AND slug = ?
+					AND id::text <> ?
+			)
+		`
+
+		if err := tx.GetContext(ctx, &taken, r.db.Rebind(query), *slug, excludeId); err != nil {
+			log.Error().Err(err).Str("slug", *slug).Msg("repository::categorySlug - Failed to check slug")
+			return "", err
+		}
+
+		if taken {
+			return "", errmsg.NewCustomErrors(409, errmsg.WithErrors("slug", "Slug sudah digunakan"))
+		}
+
+		return *slug, nil
+	}
+
+	base := pkg.Slugify(name, 90)
+	if base == "" {
+		base = "kategori"
+	}
+
+	var used []string
+	query := `
+		SELECT slug
+		FROM categories
+		WHERE
+			deleted_at IS NULL
+			AND (slug = ? OR slug LIKE ? || '-%')
+	`
+
+	if err := tx.SelectContext(ctx, &used, r.db.Rebind(query), base, base); err != nil {
+		log.Error().Err(err).Str("slug", base).Msg("repository::categorySlug - Failed to get used slugs")
+		return "", err
+	}
+
+	if !slices.Contains(used, base) {
+		return base, nil
+	}
+
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s-%d", base, n); !slices.Contains(used, candidate) {
+			return candidate, nil
+		}
+	}
+}
+
+// lockCategory locks a live category for the rest of the transaction.
+func (r *categoryRepository) lockCategory(ctx context.Context, tx *sqlx.Tx, id string) error {
+	var locked string
+
+	err := tx.GetContext(ctx, &locked, r.db.Rebind(`SELECT id FROM categories WHERE id = ? AND deleted_at IS NULL FOR UPDATE`), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errmsg.NewCustomErrors(404, errmsg.WithMessage("Kategori tidak ditemukan"))
+		}
+		log.Error().Err(err).Str("id", id).Msg("repository::lockCategory - Failed to lock category")
+		return err
+	}
+
+	return nil
+}
+
+// lockReference locks a live category the request points to through field and returns
+// its path, so that it cannot be deleted or moved halfway through.
+func (r *categoryRepository) lockReference(ctx context.Context, tx *sqlx.Tx, id, field string) (string, error) {
+	var path string
+
+	err := tx.GetContext(ctx, &path, r.db.Rebind(`SELECT path FROM categories WHERE id = ? AND deleted_at IS NULL FOR SHARE`), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errmsg.NewCustomErrors(404, errmsg.WithErrors(field, "Kategori tidak ditemukan"))
+		}
+		log.Error().Err(err).Str("id", id).Msg("repository::lockReference - Failed to lock category")
+		return "", err
+	}
+
+	return path, nil
+}
+
+// sameIds tells whether both lists hold the same ids, regardless of their order.
+func sameIds(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	seen := make(map[string]bool, len(a))
+	for _, id := range a {
+		seen[strings.ToLower(id)] = true
+	}
+
+	for _, id := range b {
+		if !seen[strings.ToLower(id)] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"codebase-app/internal/module/category/entity"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/outbox"
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (r *categoryRepository) MergeCategory(ctx context.Context, req *entity.MergeCategoryRequest) (*entity.MergeCategoryResponse, error) {
+	var resp = &entity.MergeCategoryResponse{TargetId: req.TargetId}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = r.lockCategory(ctx, tx, req.SourceId); err != nil {
+		return nil, err
+	}
+
+	targetPath, err := r.lockReference(ctx, tx, req.TargetId, "target_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(targetPath, "/"+req.SourceId+"/") {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("target_id", "Kategori tujuan tidak boleh berada di dalam kategori yang digabungkan"))
+	}
+
+	// deleted products move as well, so that a restored product lands in a live category
+	result, err := tx.ExecContext(ctx, r.db.Rebind(`UPDATE products SET category_id = ?, updated_at = NOW() WHERE category_id = ?`), req.TargetId, req.SourceId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to move products")
+		return nil, err
+	}
+
+	moved, _ := result.RowsAffected()
+	resp.MovedProducts = int(moved)
+
+	// the subcategories keep their order, after the current children of the target
+	var sortOrder int
+	if err = tx.GetContext(ctx, &sortOrder, r.db.Rebind(nextSortOrderQuery), req.TargetId); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to get sort order")
+		return nil, err
+	}
+
+	query := `
+		UPDATE categories
+		SET
+			parent_id = ?,
+			sort_order = sort_order + ?,
+			updated_at = NOW()
+		WHERE
+			parent_id = ?
+			AND deleted_at IS NULL
+	`
+
+	result, err = tx.ExecContext(ctx, r.db.Rebind(query), req.TargetId, sortOrder, req.SourceId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to move subcategories")
+		return nil, err
+	}
+
+	moved, _ = result.RowsAffected()
+	resp.MovedCategories = int(moved)
+
+	// shops excluding both categories from their recommendations keep a single rule
+	query = `
+		DELETE FROM related_exclusions e
+		WHERE
+			e.type = 'category'
+			AND e.target_id = ?
+			AND EXISTS (
+				SELECT 1
+				FROM related_exclusions t
+				WHERE
+					t.shop_id = e.shop_id
+					AND t.type = 'category'
+					AND t.target_id = ?
+			)
+	`
+
+	if _, err = tx.ExecContext(ctx, r.db.Rebind(query), req.SourceId, req.TargetId); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to delete duplicate related exclusions")
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(`UPDATE related_exclusions SET target_id = ? WHERE type = 'category' AND target_id = ?`), req.TargetId, req.SourceId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to move related exclusions")
+		return nil, err
+	}
+
+	// earlier merges into the source now redirect straight to the target
+	_, err = tx.ExecContext(ctx, r.db.Rebind(`UPDATE category_redirects SET target_id = ? WHERE target_id = ?`), req.TargetId, req.SourceId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to update redirects")
+		return nil, err
+	}
+
+	query = `
+		INSERT INTO category_redirects (source_id, target_id, merged_by)
+		VALUES (?, ?, NULLIF(?, '')::uuid)
+	`
+
+	if _, err = tx.ExecContext(ctx, r.db.Rebind(query), req.SourceId, req.TargetId, req.ActorId); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to create redirect")
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(`UPDATE categories SET deleted_at = NOW(), updated_at = NOW() WHERE id = ?`), req.SourceId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to delete source category")
+		return nil, err
+	}
+
+	err = outbox.Publish(ctx, tx, outbox.Event{
+		AggregateType: "category",
+		AggregateId:   req.SourceId,
+		EventType:     "category.merged",
+		Payload: map[string]any{
+			"source_id":        req.SourceId,
+			"target_id":        req.TargetId,
+			"moved_products":   resp.MovedProducts,
+			"moved_categories": resp.MovedCategories,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::MergeCategory - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
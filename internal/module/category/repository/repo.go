@@ -3,7 +3,9 @@ package repository
 import (
 	"codebase-app/internal/module/category/entity"
 	"codebase-app/internal/module/category/ports"
+	"codebase-app/pkg/errmsg"
 	"context"
+	"database/sql"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
@@ -21,6 +23,29 @@ func NewCategoryRepository(db *sqlx.DB) *categoryRepository {
 	}
 }
 
+// categoryItemColumns selects an entity.CategoryItem from categories c joined with
+// category_translations ct. The product count covers the whole subtree and leaves out
+// the products of deleted or suspended shops, as the product listings do.
+const categoryItemColumns = `
+	c.id,
+	c.parent_id,
+	COALESCE(ct.name, c.name) AS name,
+	c.slug,
+	c.icon_url,
+	(
+		SELECT COUNT(*)
+		FROM products p
+		JOIN
+			categories d ON d.id = p.category_id
+		JOIN
+			shops s ON s.id = p.shop_id AND s.deleted_at IS NULL
+		WHERE
+			p.deleted_at IS NULL
+			AND d.path LIKE c.path || '%'
+			AND s.verification_status IS DISTINCT FROM 'suspended'
+	) AS product_count
+`
+
 func (r *categoryRepository) GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error) {
 	type dao struct {
 		TotalData int `db:"total_data"`
@@ -35,15 +60,22 @@ func (r *categoryRepository) GetCategories(ctx context.Context, req *entity.Cate
 
 	query := `
 		SELECT
-			COUNT(id) OVER() as total_data,
-			id,
-			name
-		FROM categories
+			COUNT(c.id) OVER() as total_data,
+			` + categoryItemColumns + `
+		FROM categories c
+		LEFT JOIN
+			category_translations ct ON ct.category_id = c.id AND ct.locale = ?
 		WHERE
-			deleted_at IS NULL
+			c.deleted_at IS NULL
+		ORDER BY c.depth, c.sort_order, COALESCE(ct.name, c.name)
+		LIMIT ? OFFSET ?
 	`
 
-	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query))
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.Locale,
+		req.Paginate,
+		(req.Page-1)*req.Paginate,
+	)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::GetCategories - Failed to get categories")
 		return nil, err
@@ -61,3 +93,177 @@ func (r *categoryRepository) GetCategories(ctx context.Context, req *entity.Cate
 
 	return resp, nil
 }
+
+func (r *categoryRepository) GetCategory(ctx context.Context, req *entity.GetCategoryRequest) (*entity.GetCategoryResponse, error) {
+	var resp = new(entity.GetCategoryResponse)
+
+	// a live category wins over the latest merged one answering to the same id or slug
+	query := `
+		SELECT
+			` + categoryItemColumns + `,
+			COALESCE(ct.description, c.description) AS description
+		FROM categories c
+		LEFT JOIN
+			category_translations ct ON ct.category_id = c.id AND ct.locale = ?
+		WHERE
+			c.deleted_at IS NULL
+			AND c.id = (
+				SELECT m.id
+				FROM (
+					SELECT id, 0 AS priority, NULL::timestamptz AS merged_at
+					FROM categories
+					WHERE
+						deleted_at IS NULL
+						AND (id::text = ? OR slug = ?)
+					UNION ALL
+					SELECT cr.target_id, 1, cr.created_at
+					FROM category_redirects cr
+					JOIN
+						categories o ON o.id = cr.source_id
+					WHERE
+						o.id::text = ? OR o.slug = ?
+				) m
+				ORDER BY m.priority, m.merged_at DESC
+				LIMIT 1
+			)
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Locale,
+		req.IdOrSlug, req.IdOrSlug,
+		req.IdOrSlug, req.IdOrSlug,
+	).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Kategori tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetCategory - Failed to get category")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) GetCategoryTree(ctx context.Context, req *entity.CategoryTreeRequest) ([]entity.CategoryNode, error) {
+	var resp = make([]entity.CategoryNode, 0)
+
+	query := `
+		SELECT
+			c.id,
+			c.parent_id,
+			COALESCE(ct.name, c.name) AS name,
+			c.slug,
+			c.icon_url
+		FROM categories c
+		LEFT JOIN
+			category_translations ct ON ct.category_id = c.id AND ct.locale = ?
+		WHERE
+			c.deleted_at IS NULL
+		ORDER BY c.depth, c.sort_order, COALESCE(ct.name, c.name)
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), req.Locale)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetCategoryTree - Failed to get categories")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) GetCategoryChildren(ctx context.Context, req *entity.CategoryChildrenRequest) (*entity.CategoryChildrenResponse, error) {
+	var (
+		resp = new(entity.CategoryChildrenResponse)
+		id   string
+	)
+	resp.Items = make([]entity.CategoryItem, 0)
+
+	// a merged category answers with the children of the category it was merged into
+	query := `
+		SELECT id
+		FROM categories
+		WHERE
+			deleted_at IS NULL
+			AND id = COALESCE((SELECT target_id FROM category_redirects WHERE source_id = ?), ?)
+	`
+
+	err := r.db.GetContext(ctx, &id, r.db.Rebind(query), req.Id, req.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Kategori tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetCategoryChildren - Failed to get category")
+		return nil, err
+	}
+
+	query = `
+		SELECT ` + categoryItemColumns + `
+		FROM categories c
+		LEFT JOIN
+			category_translations ct ON ct.category_id = c.id AND ct.locale = ?
+		WHERE
+			c.deleted_at IS NULL
+			AND c.parent_id = ?
+		ORDER BY c.sort_order, COALESCE(ct.name, c.name)
+	`
+
+	err = r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.Locale, id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetCategoryChildren - Failed to get categories")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *categoryRepository) UpsertCategoryTranslation(ctx context.Context, req *entity.UpsertCategoryTranslationRequest) error {
+	query := `
+		INSERT INTO category_translations (category_id, locale, name, description)
+		SELECT id, ?, ?, ?
+		FROM categories
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+		ON CONFLICT (category_id, locale)
+		DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			updated_at = NOW()
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		req.Locale,
+		req.Name,
+		req.Description,
+		req.CategoryId,
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpsertCategoryTranslation - Failed to upsert category translation")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Kategori tidak ditemukan"))
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) DeleteCategoryTranslation(ctx context.Context, req *entity.DeleteCategoryTranslationRequest) error {
+	query := `
+		DELETE FROM category_translations
+		WHERE category_id = ? AND locale = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.CategoryId, req.Locale)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteCategoryTranslation - Failed to delete category translation")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Terjemahan kategori tidak ditemukan"))
+	}
+
+	return nil
+}
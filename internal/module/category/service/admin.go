@@ -0,0 +1,57 @@
+package service
+
+import (
+	"codebase-app/internal/module/category/entity"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"strings"
+)
+
+func (s *categoryService) CreateCategory(ctx context.Context, req *entity.CreateCategoryRequest) (*entity.CreateCategoryResponse, error) {
+	req.ParentId = rootIfEmpty(req.ParentId)
+
+	return s.repo.CreateCategory(ctx, req)
+}
+
+func (s *categoryService) UpdateCategory(ctx context.Context, req *entity.UpdateCategoryRequest) (*entity.UpdateCategoryResponse, error) {
+	if req.ParentId != nil && *req.ParentId == "" {
+		req.ParentId, req.Root = nil, true
+	}
+
+	if req.Root && req.ParentId != nil {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("parent_id", "parent_id tidak boleh diisi bersama root"))
+	}
+
+	return s.repo.UpdateCategory(ctx, req)
+}
+
+func (s *categoryService) DeleteCategory(ctx context.Context, req *entity.DeleteCategoryRequest) (*entity.DeleteCategoryResponse, error) {
+	return s.repo.DeleteCategory(ctx, req)
+}
+
+func (s *categoryService) ReorderCategories(ctx context.Context, req *entity.ReorderCategoriesRequest) error {
+	req.ParentId = rootIfEmpty(req.ParentId)
+
+	return s.repo.ReorderCategories(ctx, req)
+}
+
+func (s *categoryService) MergeCategory(ctx context.Context, req *entity.MergeCategoryRequest) (*entity.MergeCategoryResponse, error) {
+	if strings.EqualFold(req.SourceId, req.TargetId) {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("target_id", "Kategori tujuan harus berbeda dengan kategori yang digabungkan"))
+	}
+
+	return s.repo.MergeCategory(ctx, req)
+}
+
+func (s *categoryService) UpdateCategoryIcon(ctx context.Context, req *entity.UpdateCategoryIconRequest) (*entity.UpdateCategoryIconResponse, error) {
+	return s.repo.UpdateCategoryIcon(ctx, req)
+}
+
+// rootIfEmpty treats an empty parent id the same as a missing one.
+func rootIfEmpty(parentId *string) *string {
+	if parentId != nil && *parentId == "" {
+		return nil
+	}
+
+	return parentId
+}
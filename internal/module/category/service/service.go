@@ -1,8 +1,10 @@
 package service
 
 import (
+	"codebase-app/internal/infrastructure/config"
 	"codebase-app/internal/module/category/entity"
 	"codebase-app/internal/module/category/ports"
+	"codebase-app/pkg/locale"
 	"context"
 )
 
@@ -21,3 +23,53 @@ func NewCategoryService(repo ports.CategoryRepository) *categoryService {
 func (s *categoryService) GetCategories(ctx context.Context, req *entity.CategoriesRequest) (*entity.CategoriesResponse, error) {
 	return s.repo.GetCategories(ctx, req)
 }
+
+func (s *categoryService) GetCategory(ctx context.Context, req *entity.GetCategoryRequest) (*entity.GetCategoryResponse, error) {
+	return s.repo.GetCategory(ctx, req)
+}
+
+func (s *categoryService) GetCategoryTree(ctx context.Context, req *entity.CategoryTreeRequest) (*entity.CategoryTreeResponse, error) {
+	categories, err := s.repo.GetCategoryTree(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]entity.CategoryNode)
+	for _, c := range categories {
+		var parentId string
+		if c.ParentId != nil {
+			parentId = *c.ParentId
+		}
+		children[parentId] = append(children[parentId], c)
+	}
+
+	return &entity.CategoryTreeResponse{Items: buildCategoryTree(children, "")}, nil
+}
+
+// buildCategoryTree nests the categories below parentId, the roots live under "".
+// Categories whose parent is deleted are left out together with their subtree.
+func buildCategoryTree(children map[string][]entity.CategoryNode, parentId string) []entity.CategoryNode {
+	nodes := make([]entity.CategoryNode, 0, len(children[parentId]))
+	for _, node := range children[parentId] {
+		node.Children = buildCategoryTree(children, node.Id)
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+func (s *categoryService) GetCategoryChildren(ctx context.Context, req *entity.CategoryChildrenRequest) (*entity.CategoryChildrenResponse, error) {
+	return s.repo.GetCategoryChildren(ctx, req)
+}
+
+func (s *categoryService) UpsertCategoryTranslation(ctx context.Context, req *entity.UpsertCategoryTranslationRequest) error {
+	if err := locale.CheckTranslatable(req.Locale, config.Envs.App.DefaultLocale, config.Envs.App.SupportedLocales); err != nil {
+		return err
+	}
+
+	return s.repo.UpsertCategoryTranslation(ctx, req)
+}
+
+func (s *categoryService) DeleteCategoryTranslation(ctx context.Context, req *entity.DeleteCategoryTranslationRequest) error {
+	return s.repo.DeleteCategoryTranslation(ctx, req)
+}
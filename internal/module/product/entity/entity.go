@@ -1,6 +1,10 @@
 package entity
 
-import "codebase-app/pkg/types"
+import (
+	"codebase-app/pkg/fieldset"
+	"codebase-app/pkg/types"
+	"time"
+)
 
 type CreateProductRequest struct {
 	ShopId     string `json:"shop_id" validate:"uuid" db:"shop_id"`
@@ -10,6 +14,11 @@ type CreateProductRequest struct {
 	Description string `json:"description" validate:"required,max=255" db:"description"`
 	Price       int    `json:"price" validate:"required,gte=0" db:"price"`
 	Stock       int    `json:"stock" validate:"required,gte=0" db:"stock"`
+
+	// LowStockThreshold overrides the shop threshold when set.
+	LowStockThreshold *int `json:"low_stock_threshold" validate:"omitempty,gte=0" db:"low_stock_threshold"`
+
+	Tags []string `json:"tags" validate:"omitempty,max=10,dive,required,max=30" db:"-"`
 }
 
 type CreateProductResponse struct {
@@ -18,6 +27,15 @@ type CreateProductResponse struct {
 
 type GetProductRequest struct {
 	Id string `validate:"uuid" db:"id"`
+
+	/// Example: expand=shop,category
+	Expand string `query:"expand" validate:"omitempty,csv_oneof=shop category"`
+
+	Locale string `query:"-"`
+}
+
+func (r *GetProductRequest) ExpandList() []string {
+	return splitList(r.Expand)
 }
 
 type GetExistingProductResponse struct {
@@ -27,12 +45,18 @@ type GetExistingProductResponse struct {
 }
 
 type GetProductItem struct {
-	Name         string `json:"name" db:"name"`
-	Description  string `json:"description" db:"description"`
-	Price        int    `json:"price" validate:"required" db:"price"`
-	Stock        int    `json:"stock" validate:"required" db:"stock"`
-	CategoryId   string `json:"category_id" validate:"required" db:"category_id"`
-	CategoryName string `json:"category_name" validate:"required" db:"category_name"`
+	Id            string    `json:"id" db:"id"`
+	ShopId        string    `json:"shop_id" db:"shop_id"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	Name          string    `json:"name" db:"name"`
+	Description   string    `json:"description" db:"description"`
+	Price         int       `json:"price" validate:"required" db:"price"`
+	Stock         int       `json:"stock" validate:"required" db:"stock"`
+	CategoryId    string    `json:"category_id" validate:"required" db:"category_id"`
+	CategoryName  string    `json:"category_name" validate:"required" db:"category_name"`
+	Purchasable   bool      `json:"purchasable" db:"purchasable"`
+	ShopSuspended bool      `json:"-" db:"shop_suspended"`
 }
 
 type CategoryItem struct {
@@ -40,12 +64,29 @@ type CategoryItem struct {
 	CategoryName string `json:"name" validate:"required" db:"category_name"`
 }
 
+type ShopItem struct {
+	Id          string `json:"id" db:"id"`
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+}
+
 type GetProductResponse struct {
-	Name        string       `json:"name" db:"name"`
-	Description string       `json:"description" db:"description"`
-	Price       int          `json:"price" validate:"required" db:"price"`
-	Stock       int          `json:"stock" validate:"required" db:"stock"`
-	Category    CategoryItem `json:"category"`
+	Id          string         `json:"id" db:"id"`
+	ShopId      string         `json:"shop_id" db:"shop_id"`
+	Name        string         `json:"name" db:"name"`
+	Description string         `json:"description" db:"description"`
+	Price       int            `json:"price" validate:"required" db:"price"`
+	Stock       int            `json:"stock" validate:"required" db:"stock"`
+	Category    CategoryItem   `json:"category"`
+	Breadcrumbs []CategoryItem `json:"breadcrumbs"`
+	Tags        []string       `json:"tags"`
+	Purchasable bool           `json:"purchasable" db:"purchasable"`
+	Shop        *ShopItem      `json:"shop,omitempty"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+
+	// ShopSuspended is only ever true for the shop members, everyone else gets a 404.
+	ShopSuspended bool `json:"shop_suspended"`
 }
 
 type DeleteProductRequest struct {
@@ -60,6 +101,12 @@ type UpdateProductRequest struct {
 	Description string `json:"description" validate:"required" db:"description"`
 	Price       int    `json:"price" validate:"required" db:"price"`
 	Stock       int    `json:"stock" validate:"required" db:"stock"`
+
+	// LowStockThreshold is left unchanged when omitted, null falls back to the shop threshold.
+	LowStockThreshold types.Nullable[int] `json:"low_stock_threshold" validate:"omitempty,gte=0" db:"-"`
+
+	// Tags replace the current tags, they are left unchanged when omitted.
+	Tags []string `json:"tags" validate:"omitempty,max=10,dive,required,max=30" db:"-"`
 }
 
 type UpdateProductResponse struct {
@@ -69,6 +116,9 @@ type UpdateProductResponse struct {
 type ProductsByShopIdRequest struct {
 	ShopId string `validate:"uuid" db:"shop_id"`
 	ProductsRequest
+
+	// IncludeSuspended lists the products of a suspended shop, set for the shop members only.
+	IncludeSuspended bool `query:"-"`
 }
 
 type ProductsRequest struct {
@@ -81,7 +131,25 @@ type ProductsRequest struct {
 	MaxPrice int    `query:"max_price" validate:"gte=0"`
 
 	/// Example: category_ids=08362b22-f51d-40b1-a16b-49af90d561d9,3b4da768-e480-4cbb-b7fe-8b229123b50a
-	CategoryIds string `query:"category_ids"`
+	CategoryIds string `query:"category_ids" validate:"omitempty,uuid_csv"`
+
+	/// Example: shop_ids=08362b22-f51d-40b1-a16b-49af90d561d9,3b4da768-e480-4cbb-b7fe-8b229123b50a
+	ShopIds string `query:"shop_ids" validate:"omitempty,uuid_csv"`
+
+	// Availability
+	InStock  bool `query:"in_stock"`
+	MinStock int  `query:"min_stock" validate:"gte=0"`
+
+	// Sort
+	Sort string `query:"sort" validate:"omitempty,oneof=newest popular most_viewed"`
+
+	/// Example: expand=shop,category
+	Expand string `query:"expand" validate:"omitempty,csv_oneof=shop category"`
+
+	/// Example: fields=id,name,price
+	Fields string `query:"fields" validate:"omitempty,csv_oneof=id shop_id category_id name price stock view_count purchasable created_at updated_at"`
+
+	Locale string `query:"-"`
 }
 
 func (r *ProductsRequest) SetDefault() {
@@ -93,16 +161,313 @@ func (r *ProductsRequest) SetDefault() {
 		r.Paginate = 10
 	}
 
+	if r.Sort == "" {
+		r.Sort = "newest"
+	}
+}
+
+func (r *ProductsRequest) CategoryIdList() []string {
+	return splitList(r.CategoryIds)
+}
+
+func (r *ProductsRequest) ShopIdList() []string {
+	return splitList(r.ShopIds)
+}
+
+func (r *ProductsRequest) ExpandList() []string {
+	return splitList(r.Expand)
+}
+
+func (r *ProductsRequest) FieldList() []string {
+	return fieldset.Parse(r.Fields)
+}
+
+// splitList splits a comma separated query value, ex: "a, b,c" => [a b c]
+func splitList(value string) []string {
+	return fieldset.Parse(value)
 }
 
 type ProductItem struct {
-	Id    string `json:"id" db:"id"`
-	Name  string `json:"name" db:"name"`
-	Price int    `json:"price" validate:"required" db:"price"`
-	Stock int    `json:"stock" validate:"required" db:"stock"`
+	Id         string `json:"id" db:"id"`
+	ShopId     string `json:"shop_id" db:"shop_id"`
+	CategoryId string `json:"category_id" db:"category_id"`
+	Name       string `json:"name" db:"name"`
+	Price      int    `json:"price" validate:"required" db:"price"`
+	Stock      int    `json:"stock" validate:"required" db:"stock"`
+	ViewCount  int64  `json:"view_count" db:"view_count"`
+
+	// Purchasable is false while the shop is closed or on vacation.
+	Purchasable bool `json:"purchasable" db:"purchasable"`
+
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at" db:"updated_at"`
+	Shop      *ShopItem     `json:"shop,omitempty" db:"-"`
+	Category  *CategoryItem `json:"category,omitempty" db:"-"`
 }
 
 type ProductsResponse struct {
 	Items []ProductItem `json:"items"`
 	Meta  types.Meta    `json:"meta"`
 }
+
+type ProductViewStatsRequest struct {
+	Id   string `validate:"uuid" db:"id"`
+	Days int    `query:"days" validate:"omitempty,min=1,max=90"`
+}
+
+func (r *ProductViewStatsRequest) SetDefault() {
+	if r.Days < 1 {
+		r.Days = 30
+	}
+}
+
+type ProductViewDaily struct {
+	Date  string `json:"date" db:"view_date"`
+	Views int    `json:"views" db:"views"`
+}
+
+type ProductViewStatsResponse struct {
+	ProductId       string             `json:"product_id" db:"id"`
+	TotalViews      int64              `json:"total_views" db:"view_count"`
+	PendingViews    int64              `json:"pending_views"`
+	PopularityScore float64            `json:"popularity_score" db:"popularity_score"`
+	Daily           []ProductViewDaily `json:"daily"`
+}
+
+type LowStockProductsRequest struct {
+	ShopId   string `validate:"uuid" db:"shop_id"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *LowStockProductsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type LowStockProductItem struct {
+	Id                string `json:"id" db:"id"`
+	Name              string `json:"name" db:"name"`
+	Stock             int    `json:"stock" db:"stock"`
+	LowStockThreshold int    `json:"low_stock_threshold" db:"low_stock_threshold"`
+}
+
+type LowStockProductsResponse struct {
+	Items []LowStockProductItem `json:"items"`
+	Meta  types.Meta            `json:"meta"`
+}
+
+type StockNotificationsRequest struct {
+	ShopId   string `validate:"uuid" db:"shop_id"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *StockNotificationsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type StockNotificationItem struct {
+	Id          string     `json:"id" db:"id"`
+	ProductId   string     `json:"product_id" db:"product_id"`
+	ProductName string     `json:"product_name" db:"product_name"`
+	Stock       int        `json:"stock" db:"stock"`
+	Threshold   int        `json:"threshold" db:"threshold"`
+	Source      string     `json:"source" db:"source"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	ReadAt      *time.Time `json:"read_at" db:"read_at"`
+}
+
+type StockNotificationsResponse struct {
+	Items []StockNotificationItem `json:"items"`
+	Meta  types.Meta              `json:"meta"`
+}
+
+type ProductTranslationsRequest struct {
+	ProductId string `validate:"uuid" db:"product_id"`
+}
+
+type ProductTranslationItem struct {
+	Locale      string    `json:"locale" db:"locale"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type ProductTranslationsResponse struct {
+	Items []ProductTranslationItem `json:"items"`
+}
+
+type UpsertProductTranslationRequest struct {
+	ProductId string `validate:"uuid" db:"product_id"`
+	Locale    string `params:"locale" validate:"required,bcp47_language_tag" db:"locale"`
+
+	Name        string `json:"name" validate:"required,max=255" db:"name"`
+	Description string `json:"description" validate:"required,max=255" db:"description"`
+}
+
+type DeleteProductTranslationRequest struct {
+	ProductId string `validate:"uuid" db:"product_id"`
+	Locale    string `params:"locale" validate:"required,bcp47_language_tag" db:"locale"`
+}
+
+type RelatedProductsRequest struct {
+	Id     string `validate:"uuid" db:"id"`
+	Limit  int    `query:"limit" validate:"min=1,max=50"`
+	Locale string `query:"-"`
+}
+
+func (r *RelatedProductsRequest) SetDefault() {
+	if r.Limit < 1 {
+		r.Limit = 10
+	}
+}
+
+// RelatedCandidate is a product sharing something with the source product, along with what it
+// shares and the score it was ranked by.
+type RelatedCandidate struct {
+	Id             string  `db:"id"`
+	ShopId         string  `db:"shop_id"`
+	CategoryId     string  `db:"category_id"`
+	Name           string  `db:"name"`
+	Price          int     `db:"price"`
+	Stock          int     `db:"stock"`
+	SameCategory   bool    `db:"same_category"`
+	SameShop       bool    `db:"same_shop"`
+	SharedTags     int     `db:"shared_tags"`
+	NameSimilarity float64 `db:"name_similarity"`
+	Score          float64 `db:"score"`
+}
+
+type RelatedProductItem struct {
+	Id         string  `json:"id"`
+	ShopId     string  `json:"shop_id"`
+	CategoryId string  `json:"category_id"`
+	Name       string  `json:"name"`
+	Price      int     `json:"price"`
+	Stock      int     `json:"stock"`
+	Score      float64 `json:"score"`
+}
+
+type RelatedProductsResponse struct {
+	Items []RelatedProductItem `json:"items"`
+}
+
+type RelatedExclusionsRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+}
+
+type RelatedExclusionItem struct {
+	Id        string    `json:"id" db:"id"`
+	Type      string    `json:"type" db:"type"`
+	TargetId  string    `json:"target_id" db:"target_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type RelatedExclusionsResponse struct {
+	Items []RelatedExclusionItem `json:"items"`
+}
+
+type CreateRelatedExclusionRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+
+	Type     string `json:"type" validate:"required,oneof=product category" db:"type"`
+	TargetId string `json:"target_id" validate:"uuid" db:"target_id"`
+}
+
+type CreateRelatedExclusionResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type DeleteRelatedExclusionRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	Id     string `validate:"uuid" db:"id"`
+}
+
+type QuoteRequest struct {
+	Items []QuoteItemRequest `json:"items" validate:"required,min=1,max=50,dive"`
+
+	Locale string `json:"-"`
+}
+
+type QuoteItemRequest struct {
+	ProductId string `json:"product_id" validate:"uuid"`
+	Quantity  int    `json:"quantity" validate:"required,min=1"`
+}
+
+func (r *QuoteRequest) ProductIds() []string {
+	ids := make([]string, 0, len(r.Items))
+	for _, item := range r.Items {
+		ids = append(ids, item.ProductId)
+	}
+
+	return ids
+}
+
+type QuoteProduct struct {
+	Id          string `db:"id"`
+	ShopId      string `db:"shop_id"`
+	Name        string `db:"name"`
+	Price       int    `db:"price"`
+	Stock       int    `db:"stock"`
+	Purchasable bool   `db:"purchasable"`
+}
+
+type QuoteItem struct {
+	ProductId   string `json:"product_id"`
+	ShopId      string `json:"shop_id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Price       int    `json:"price"`
+	Quantity    int    `json:"quantity"`
+	Subtotal    int    `json:"subtotal"`
+	Purchasable bool   `json:"purchasable"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+type QuoteResponse struct {
+	Items []QuoteItem `json:"items"`
+
+	// Total only sums the purchasable items.
+	Total int `json:"total"`
+}
+
+type FeedRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit" validate:"gte=1,lte=50"`
+
+	Locale string `query:"-"`
+}
+
+func (r *FeedRequest) SetDefault() {
+	if r.Limit < 1 {
+		r.Limit = 20
+	}
+}
+
+type FeedItem struct {
+	Id          string    `json:"id" db:"id"`
+	ShopId      string    `json:"shop_id" db:"shop_id"`
+	ShopName    string    `json:"shop_name" db:"shop_name"`
+	Name        string    `json:"name" db:"name"`
+	Price       int       `json:"price" db:"price"`
+	Stock       int       `json:"stock" db:"stock"`
+	Purchasable bool      `json:"purchasable" db:"purchasable"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type FeedResponse struct {
+	Items []FeedItem       `json:"items"`
+	Meta  types.CursorMeta `json:"meta"`
+}
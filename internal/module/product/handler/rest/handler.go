@@ -2,40 +2,79 @@ package handler
 
 import (
 	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure"
+	"codebase-app/internal/infrastructure/config"
 	"codebase-app/internal/middleware"
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
 	"codebase-app/internal/module/product/repository"
 	"codebase-app/internal/module/product/service"
+	shopEntity "codebase-app/internal/module/shop/entity"
+	shopPorts "codebase-app/internal/module/shop/ports"
+	shopRepository "codebase-app/internal/module/shop/repository"
+	shopService "codebase-app/internal/module/shop/service"
 	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/fieldset"
 	"codebase-app/pkg/response"
+	"context"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 )
 
 type productHandler struct {
-	service ports.ProductService
+	service     ports.ProductService
+	shopService shopPorts.ShopService
 }
 
 func NewProductHandler() *productHandler {
 	var (
-		handler = new(productHandler)
-		repo    = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
-		service = service.NewProductService(repo)
+		handler  = new(productHandler)
+		repo     = repository.NewProductRepository(adapter.Adapters.ShopeefunPostgres)
+		service  = service.NewProductService(repo)
+		shopRepo = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
 	)
 	handler.service = service
+	handler.shopService = shopService.NewShopService(shopRepo)
+
+	var (
+		flushInterval   = time.Duration(config.Envs.Product.ViewFlushInterval) * time.Second
+		refreshInterval = time.Duration(config.Envs.Product.PopularityRefreshInterval) * time.Second
+		stopFlush       = infrastructure.RunEvery("product-view-flush", flushInterval, service.FlushProductViews)
+		stopRefresh     = infrastructure.RunEvery("product-popularity-refresh", refreshInterval, service.RefreshPopularityScores)
+	)
+
+	// flush the remaining buffered views before the database connection is closed
+	adapter.Adapters.RestServer.Hooks().OnShutdown(func() error {
+		stopFlush()
+		stopRefresh()
+		return service.FlushProductViews(context.Background())
+	})
 
 	return handler
 }
 
 func (h *productHandler) Register(router fiber.Router) {
 	router.Get("/products", h.GetProducts)
-	router.Get("/shops/:shop_id/products", h.GetProductsByShopId)
+	router.Get("/shops/:shop_id/products", middleware.OptionalUserIdHeader, h.GetProductsByShopId)
+	router.Get("/shops/:shop_id/low-stock", middleware.UserIdHeader, h.GetLowStockProducts)
+	router.Get("/shops/:shop_id/stock-notifications", middleware.UserIdHeader, h.GetStockNotifications)
+	router.Get("/shops/:shop_id/related-exclusions", middleware.UserIdHeader, h.GetRelatedExclusions)
+	router.Post("/shops/:shop_id/related-exclusions", middleware.UserIdHeader, h.CreateRelatedExclusion)
+	router.Delete("/shops/:shop_id/related-exclusions/:id", middleware.UserIdHeader, h.DeleteRelatedExclusion)
 	router.Post("/products", middleware.UserIdHeader, h.CreateProduct)
-	router.Get("/products/:id", h.GetProduct)
+	router.Post("/products/quote", h.Quote)
+	router.Get("/products/feed", middleware.UserIdHeader, h.GetFeed)
+	router.Get("/products/:id", middleware.OptionalUserIdHeader, h.GetProduct)
 	router.Delete("/products/:id", middleware.UserIdHeader, h.DeleteProduct)
 	router.Patch("/products/:id", middleware.UserIdHeader, h.UpdateProduct)
+	router.Get("/products/:id/related", h.GetRelatedProducts)
+	router.Get("/products/:id/views", middleware.UserIdHeader, h.GetProductViewStats)
+	router.Get("/products/:id/translations", middleware.UserIdHeader, h.GetProductTranslations)
+	router.Put("/products/:id/translations/:locale", middleware.UserIdHeader, h.UpsertProductTranslation)
+	router.Delete("/products/:id/translations/:locale", middleware.UserIdHeader, h.DeleteProductTranslation)
 }
 
 func (h *productHandler) CreateProduct(c *fiber.Ctx) error {
@@ -43,6 +82,7 @@ func (h *productHandler) CreateProduct(c *fiber.Ctx) error {
 		req = new(entity.CreateProductRequest)
 		ctx = c.Context()
 		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
 	)
 
 	if err := c.BodyParser(req); err != nil {
@@ -56,6 +96,16 @@ func (h *productHandler) CreateProduct(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	err := h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsWrite,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
 	resp, err := h.service.CreateProduct(ctx, req)
 	if err != nil {
 		code, errs := errmsg.Errors[error](err)
@@ -71,9 +121,16 @@ func (h *productHandler) GetProduct(c *fiber.Ctx) error {
 		req = new(entity.GetProductRequest)
 		ctx = c.Context()
 		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
 	)
 
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetProduct - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
 	req.Id = c.Params("id")
+	req.Locale = middleware.GetLocale(c)
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::GetProduct - Validate request body")
@@ -87,6 +144,11 @@ func (h *productHandler) GetProduct(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	if resp.ShopSuspended && !h.isShopMember(ctx, resp.ShopId, l.UserId) {
+		code, errs := errmsg.Errors[error](errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan")))
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -100,7 +162,6 @@ func (h *productHandler) DeleteProduct(c *fiber.Ctx) error {
 	)
 
 	req.Id = c.Params("id")
-	OwnerId := l.UserId
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteProduct - Validate request body")
@@ -115,11 +176,14 @@ func (h *productHandler) DeleteProduct(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
-	if respExistingProduct.UserId != OwnerId {
-		log.Warn().Err(err).Msg("handler::DeleteProduct - Unauthorized")
-		return c.Status(403).JSON(response.Error(
-			"Terlarang: anda tidak diizinkan untuk mengakses resource ini",
-		))
+	err = h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     respExistingProduct.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsWrite,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
 	}
 
 	err = h.service.DeleteProduct(ctx, req)
@@ -146,7 +210,6 @@ func (h *productHandler) UpdateProduct(c *fiber.Ctx) error {
 	}
 
 	req.Id = c.Params("id")
-	OwnerId := l.UserId
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateProduct - Validate request body")
@@ -161,11 +224,14 @@ func (h *productHandler) UpdateProduct(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
-	if respExistingProduct.UserId != OwnerId {
-		log.Warn().Err(err).Msg("handler::UpdateProduct - Unauthorized")
-		return c.Status(403).JSON(response.Error(
-			"Terlarang: anda tidak diizinkan untuk mengakses resource ini",
-		))
+	err = h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     respExistingProduct.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsWrite,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
 	}
 
 	resp, err := h.service.UpdateProduct(ctx, req)
@@ -189,6 +255,7 @@ func (h *productHandler) GetProducts(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
+	req.Locale = middleware.GetLocale(c)
 	req.SetDefault()
 
 	if err := v.Validate(req); err != nil {
@@ -203,6 +270,16 @@ func (h *productHandler) GetProducts(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	if fields := req.FieldList(); len(fields) > 0 {
+		items, err := fieldset.Project(resp.Items, append(fields, req.ExpandList()...)...)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("handler::GetProducts - Project fields")
+			return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+		}
+
+		return c.Status(fiber.StatusOK).JSON(response.Success(fiber.Map{"items": items, "meta": resp.Meta}, ""))
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
 
@@ -211,6 +288,7 @@ func (h *productHandler) GetProductsByShopId(c *fiber.Ctx) error {
 		req = new(entity.ProductsByShopIdRequest)
 		ctx = c.Context()
 		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
 	)
 
 	if err := c.QueryParser(req); err != nil {
@@ -219,6 +297,7 @@ func (h *productHandler) GetProductsByShopId(c *fiber.Ctx) error {
 	}
 
 	req.ShopId = c.Params("shop_id")
+	req.Locale = middleware.GetLocale(c)
 	req.SetDefault()
 
 	if err := v.Validate(req); err != nil {
@@ -227,11 +306,509 @@ func (h *productHandler) GetProductsByShopId(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	req.IncludeSuspended = h.isShopMember(ctx, req.ShopId, l.UserId)
+
 	resp, err := h.service.GetProductsByShopId(ctx, req)
 	if err != nil {
 		code, errs := errmsg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	if fields := req.FieldList(); len(fields) > 0 {
+		items, err := fieldset.Project(resp.Items, append(fields, req.ExpandList()...)...)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("handler::GetProductsByShopId - Project fields")
+			return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+		}
+
+		return c.Status(fiber.StatusOK).JSON(response.Success(fiber.Map{"items": items, "meta": resp.Meta}, ""))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetProductViewStats(c *fiber.Ctx) error {
+	var (
+		req           = new(entity.ProductViewStatsRequest)
+		reqGetProduct = new(entity.GetProductRequest)
+		ctx           = c.Context()
+		v             = adapter.Adapters.Validator
+		l             = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetProductViewStats - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetProductViewStats - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	reqGetProduct.Id = req.Id
+	respExistingProduct, err := h.service.VerifyProductExists(ctx, reqGetProduct)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     respExistingProduct.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsRead,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetProductViewStats(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetLowStockProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.LowStockProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetLowStockProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("shop_id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetLowStockProducts - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsRead,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetLowStockProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetStockNotifications(c *fiber.Ctx) error {
+	var (
+		req = new(entity.StockNotificationsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetStockNotifications - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("shop_id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetStockNotifications - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsRead,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetStockNotifications(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetProductTranslations(c *fiber.Ctx) error {
+	var (
+		req           = new(entity.ProductTranslationsRequest)
+		reqGetProduct = new(entity.GetProductRequest)
+		ctx           = c.Context()
+		v             = adapter.Adapters.Validator
+		l             = middleware.GetLocals(c)
+	)
+
+	req.ProductId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetProductTranslations - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	reqGetProduct.Id = req.ProductId
+	respExistingProduct, err := h.service.VerifyProductExists(ctx, reqGetProduct)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     respExistingProduct.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsRead,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetProductTranslations(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) UpsertProductTranslation(c *fiber.Ctx) error {
+	var (
+		req           = new(entity.UpsertProductTranslationRequest)
+		reqGetProduct = new(entity.GetProductRequest)
+		ctx           = c.Context()
+		v             = adapter.Adapters.Validator
+		l             = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpsertProductTranslation - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ProductId = c.Params("id")
+	req.Locale = strings.ToLower(c.Params("locale"))
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpsertProductTranslation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	reqGetProduct.Id = req.ProductId
+	respExistingProduct, err := h.service.VerifyProductExists(ctx, reqGetProduct)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     respExistingProduct.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsWrite,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.service.UpsertProductTranslation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *productHandler) DeleteProductTranslation(c *fiber.Ctx) error {
+	var (
+		req           = new(entity.DeleteProductTranslationRequest)
+		reqGetProduct = new(entity.GetProductRequest)
+		ctx           = c.Context()
+		v             = adapter.Adapters.Validator
+		l             = middleware.GetLocals(c)
+	)
+
+	req.ProductId = c.Params("id")
+	req.Locale = strings.ToLower(c.Params("locale"))
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteProductTranslation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	reqGetProduct.Id = req.ProductId
+	respExistingProduct, err := h.service.VerifyProductExists(ctx, reqGetProduct)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     respExistingProduct.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsWrite,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.service.DeleteProductTranslation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *productHandler) GetRelatedProducts(c *fiber.Ctx) error {
+	var (
+		req = new(entity.RelatedProductsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetRelatedProducts - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+	req.Locale = middleware.GetLocale(c)
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetRelatedProducts - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetRelatedProducts(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) GetRelatedExclusions(c *fiber.Ctx) error {
+	var (
+		req = new(entity.RelatedExclusionsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.ShopId = c.Params("shop_id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetRelatedExclusions - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermProductsRead,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetRelatedExclusions(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) CreateRelatedExclusion(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateRelatedExclusionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateRelatedExclusion - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("shop_id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateRelatedExclusion - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateRelatedExclusion(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *productHandler) DeleteRelatedExclusion(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteRelatedExclusionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.ShopId = c.Params("shop_id")
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteRelatedExclusion - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.service.DeleteRelatedExclusion(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *productHandler) Quote(c *fiber.Ctx) error {
+	var (
+		req = new(entity.QuoteRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::Quote - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Locale = middleware.GetLocale(c)
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::Quote - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.Quote(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+// isShopMember reports whether the optional viewer belongs to the shop, members
+// still see the products of a suspended shop.
+func (h *productHandler) isShopMember(ctx context.Context, shopId, userId string) bool {
+	if userId == "" {
+		return false
+	}
+
+	err := h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     shopId,
+		UserId:     userId,
+		Permission: shopEntity.PermProductsRead,
+	})
+
+	return err == nil
+}
+
+func (h *productHandler) GetFeed(c *fiber.Ctx) error {
+	var (
+		req = new(entity.FeedRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetFeed - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.UserId = l.UserId
+	req.Locale = middleware.GetLocale(c)
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetFeed - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetFeed(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 }
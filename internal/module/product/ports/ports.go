@@ -2,6 +2,7 @@ package ports
 
 import (
 	"codebase-app/internal/module/product/entity"
+	"codebase-app/pkg/types"
 	"context"
 )
 
@@ -13,6 +14,22 @@ type ProductRepository interface {
 	UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error)
 	GetProducts(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error)
 	GetProductsByShopId(ctx context.Context, req *entity.ProductsByShopIdRequest) (*entity.ProductsResponse, error)
+	FlushProductViews(ctx context.Context, views map[string]int) error
+	RefreshPopularityScores(ctx context.Context) error
+	GetProductViewStats(ctx context.Context, req *entity.ProductViewStatsRequest) (*entity.ProductViewStatsResponse, error)
+	GetLowStockProducts(ctx context.Context, req *entity.LowStockProductsRequest) (*entity.LowStockProductsResponse, error)
+	GetStockNotifications(ctx context.Context, req *entity.StockNotificationsRequest) (*entity.StockNotificationsResponse, error)
+	GetShopsByIds(ctx context.Context, ids []string, locale string) ([]entity.ShopItem, error)
+	GetCategoriesByIds(ctx context.Context, ids []string, locale string) ([]entity.CategoryItem, error)
+	GetProductTranslations(ctx context.Context, req *entity.ProductTranslationsRequest) (*entity.ProductTranslationsResponse, error)
+	UpsertProductTranslation(ctx context.Context, req *entity.UpsertProductTranslationRequest) error
+	DeleteProductTranslation(ctx context.Context, req *entity.DeleteProductTranslationRequest) error
+	GetRelatedCandidates(ctx context.Context, req *entity.RelatedProductsRequest, limit int) ([]entity.RelatedCandidate, error)
+	GetRelatedExclusions(ctx context.Context, req *entity.RelatedExclusionsRequest) (*entity.RelatedExclusionsResponse, error)
+	CreateRelatedExclusion(ctx context.Context, req *entity.CreateRelatedExclusionRequest) (*entity.CreateRelatedExclusionResponse, error)
+	DeleteRelatedExclusion(ctx context.Context, req *entity.DeleteRelatedExclusionRequest) error
+	GetQuoteProducts(ctx context.Context, ids []string, locale string) ([]entity.QuoteProduct, error)
+	GetFeed(ctx context.Context, req *entity.FeedRequest, cursor *types.Cursor, limit int) ([]entity.FeedItem, error)
 }
 
 type ProductService interface {
@@ -23,4 +40,18 @@ type ProductService interface {
 	UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error)
 	GetProducts(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error)
 	GetProductsByShopId(ctx context.Context, req *entity.ProductsByShopIdRequest) (*entity.ProductsResponse, error)
+	FlushProductViews(ctx context.Context) error
+	RefreshPopularityScores(ctx context.Context) error
+	GetProductViewStats(ctx context.Context, req *entity.ProductViewStatsRequest) (*entity.ProductViewStatsResponse, error)
+	GetLowStockProducts(ctx context.Context, req *entity.LowStockProductsRequest) (*entity.LowStockProductsResponse, error)
+	GetStockNotifications(ctx context.Context, req *entity.StockNotificationsRequest) (*entity.StockNotificationsResponse, error)
+	GetProductTranslations(ctx context.Context, req *entity.ProductTranslationsRequest) (*entity.ProductTranslationsResponse, error)
+	UpsertProductTranslation(ctx context.Context, req *entity.UpsertProductTranslationRequest) error
+	DeleteProductTranslation(ctx context.Context, req *entity.DeleteProductTranslationRequest) error
+	GetRelatedProducts(ctx context.Context, req *entity.RelatedProductsRequest) (*entity.RelatedProductsResponse, error)
+	GetRelatedExclusions(ctx context.Context, req *entity.RelatedExclusionsRequest) (*entity.RelatedExclusionsResponse, error)
+	CreateRelatedExclusion(ctx context.Context, req *entity.CreateRelatedExclusionRequest) (*entity.CreateRelatedExclusionResponse, error)
+	DeleteRelatedExclusion(ctx context.Context, req *entity.DeleteRelatedExclusionRequest) error
+	Quote(ctx context.Context, req *entity.QuoteRequest) (*entity.QuoteResponse, error)
+	GetFeed(ctx context.Context, req *entity.FeedRequest) (*entity.FeedResponse, error)
 }
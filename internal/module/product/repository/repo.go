@@ -4,11 +4,15 @@ import (
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
 	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/fieldset"
+	"codebase-app/pkg/outbox"
+	"codebase-app/pkg/types"
 	"context"
 	"database/sql"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
@@ -26,28 +30,78 @@ func NewProductRepository(db *sqlx.DB) *productRepository {
 
 func (r *productRepository) CreateProduct(ctx context.Context, req *entity.CreateProductRequest) (*entity.CreateProductResponse, error) {
 	var resp = new(entity.CreateProductResponse)
-	// Your code here
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO products (shop_id, category_id, name, description, price, stock)
-		VALUES (?, ?, ?, ?, ?, ?) RETURNING id
+		INSERT INTO products (shop_id, category_id, name, description, price, stock, low_stock_threshold)
+		VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id
 	`
 
-	err := r.db.QueryRowContext(ctx, r.db.Rebind(query),
+	err = tx.QueryRowContext(ctx, r.db.Rebind(query),
 		req.ShopId,
 		req.CategoryId,
 		req.Name,
 		req.Description,
 		req.Price,
 		req.Stock,
+		req.LowStockThreshold,
 	).Scan(&resp.Id)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to create product")
 		return nil, err
 	}
 
+	if err = r.replaceProductTags(ctx, tx, resp.Id, req.Tags); err != nil {
+		return nil, err
+	}
+
+	// a product created at or below its threshold is low from the start
+	if err = r.notifyLowStock(ctx, tx, resp.Id, nil, "create"); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateProduct - Failed to commit transaction")
+		return nil, err
+	}
+
 	return resp, nil
 }
 
+// replaceProductTags replaces the tags of the product, tags are stored trimmed and lowercased.
+func (r *productRepository) replaceProductTags(ctx context.Context, tx *sqlx.Tx, productId string, tags []string) error {
+	_, err := tx.ExecContext(ctx, r.db.Rebind(`DELETE FROM product_tags WHERE product_id = ?`), productId)
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::replaceProductTags - Failed to delete product tags")
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO product_tags (product_id, tag)
+		SELECT DISTINCT ?::uuid, LOWER(BTRIM(t))
+		FROM unnest(?::text[]) t
+		WHERE BTRIM(t) <> ''
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), productId, pq.Array(tags))
+	if err != nil {
+		log.Error().Err(err).Str("product_id", productId).Msg("repository::replaceProductTags - Failed to insert product tags")
+		return err
+	}
+
+	return nil
+}
+
 func (r *productRepository) GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error) {
 	var (
 		item = new(entity.GetProductItem)
@@ -57,21 +111,33 @@ func (r *productRepository) GetProduct(ctx context.Context, req *entity.GetProdu
 	// Your code here
 	query := `
 		SELECT
-			p.name,
-			p.description,
+			p.id,
+			p.shop_id,
+			COALESCE(pt.name, p.name) AS name,
+			COALESCE(pt.description, p.description) AS description,
 			p.price,
 			p.stock,
 			p.category_id,
-			c.name AS category_name
+			COALESCE(ct.name, c.name) AS category_name,
+			shop_is_open(p.shop_id) AS purchasable,
+			COALESCE(s.verification_status = 'suspended', false) AS shop_suspended,
+			p.created_at,
+			p.updated_at
 		FROM products p
+		JOIN
+			shops s ON s.id = p.shop_id AND s.deleted_at IS NULL
 		LEFT JOIN
 			categories c ON p.category_id = c.id
+		LEFT JOIN
+			product_translations pt ON pt.product_id = p.id AND pt.locale = ?
+		LEFT JOIN
+			category_translations ct ON ct.category_id = c.id AND ct.locale = ?
 		WHERE
 			p.deleted_at IS NULL
 			AND p.id = ?
 	`
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(item)
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Locale, req.Locale, req.Id).StructScan(item)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Product not found")
@@ -82,12 +148,47 @@ func (r *productRepository) GetProduct(ctx context.Context, req *entity.GetProdu
 		}
 	}
 
+	resp.Id = item.Id
+	resp.ShopId = item.ShopId
 	resp.Name = item.Name
 	resp.Description = item.Description
 	resp.Price = item.Price
 	resp.Stock = item.Stock
 	resp.Category.CategoryId = item.CategoryId
 	resp.Category.CategoryName = item.CategoryName
+	resp.Purchasable = item.Purchasable
+	resp.ShopSuspended = item.ShopSuspended
+	resp.CreatedAt = item.CreatedAt
+	resp.UpdatedAt = item.UpdatedAt
+	resp.Tags = make([]string, 0)
+
+	err = r.db.SelectContext(ctx, &resp.Tags, r.db.Rebind(`SELECT tag FROM product_tags WHERE product_id = ? ORDER BY tag`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Failed to get product tags")
+		return nil, err
+	}
+
+	// Breadcrumbs walk the materialised path of the category, from the root down to the category itself.
+	resp.Breadcrumbs = make([]entity.CategoryItem, 0)
+	breadcrumbsQuery := `
+		SELECT
+			a.id AS category_id,
+			COALESCE(ct.name, a.name) AS category_name
+		FROM categories c
+		JOIN
+			categories a ON c.path LIKE a.path || '%'
+		LEFT JOIN
+			category_translations ct ON ct.category_id = a.id AND ct.locale = ?
+		WHERE
+			c.id = ?
+		ORDER BY a.depth
+	`
+
+	err = r.db.SelectContext(ctx, &resp.Breadcrumbs, r.db.Rebind(breadcrumbsQuery), req.Locale, item.CategoryId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProduct - Failed to get category breadcrumbs")
+		return nil, err
+	}
 
 	return resp, nil
 }
@@ -143,6 +244,18 @@ func (r *productRepository) DeleteProduct(ctx context.Context, req *entity.Delet
 func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.UpdateProductRequest) (*entity.UpdateProductResponse, error) {
 	var resp = new(entity.UpdateProductResponse)
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	before, err := r.getStockLevel(ctx, tx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		UPDATE products
 		SET
@@ -151,6 +264,7 @@ func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.Updat
 			price = ?,
 			stock = ?,
 			category_id = ?,
+			low_stock_threshold = CASE WHEN ?::boolean THEN ?::int ELSE low_stock_threshold END,
 			updated_at = NOW()
 		WHERE
 			deleted_at IS NULL
@@ -158,12 +272,13 @@ func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.Updat
 		RETURNING id
 	`
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query),
 		req.Name,
 		req.Description,
 		req.Price,
 		req.Stock,
 		req.CategoryId,
+		req.LowStockThreshold.Set, req.LowStockThreshold.Value,
 		req.Id).Scan(&resp.Id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -175,6 +290,21 @@ func (r *productRepository) UpdateProduct(ctx context.Context, req *entity.Updat
 		}
 	}
 
+	if req.Tags != nil {
+		if err = r.replaceProductTags(ctx, tx, resp.Id, req.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = r.notifyLowStock(ctx, tx, resp.Id, before, "update"); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateProduct - Failed to commit transaction")
+		return nil, err
+	}
+
 	return resp, nil
 }
 
@@ -190,70 +320,22 @@ func (r *productRepository) GetProducts(ctx context.Context, req *entity.Product
 	)
 	resp.Items = make([]entity.ProductItem, 0, req.Paginate)
 
-	query := `
+	query := localizedProducts + `
 		SELECT
 			COUNT(id) OVER() as total_data,
-			id,
-			name,
-			price,
-			stock
-		FROM products
+			` + strings.Join(productColumns(req.FieldList(), req.ExpandList()), ", ") + `
+		FROM localized_products
 		WHERE
 			deleted_at IS NULL
+			AND NOT shop_suspended
 	`
 
 	// Search and filter query
-	queries := []interface{}{}
+	queries := []interface{}{req.Locale}
+	query, queries = productsFilter(query, queries, req)
 
-	/// Filter by Category Ids
-	/// Example: category_ids=08362b22-f51d-40b1-a16b-49af90d561d9,3b4da768-e480-4cbb-b7fe-8b229123b50a
-	if len(req.CategoryIds) > 0 {
-		CategoryIdList := strings.Split(req.CategoryIds, ",")
-		query += ` AND category_id IN (`
-
-		for i, categoryId := range CategoryIdList {
-			query += `?`
-			if i < len(CategoryIdList)-1 {
-				query += ` , `
-			}
-
-			queries = append(
-				queries,
-				categoryId,
-			)
-		}
-		query += `)`
-	}
-
-	/// Filter by Price Range
-	if req.MinPrice > 0 {
-		query += ` AND price >= ?`
-		queries = append(
-			queries,
-			req.MinPrice,
-		)
-	}
-	if req.MaxPrice > req.MinPrice {
-		query += ` AND price BETWEEN ? AND ?`
-		queries = append(
-			queries,
-			req.MinPrice, req.MaxPrice,
-		)
-	}
-
-	/// Filter by Keyword on either name or description
-	if len(req.Keyword) > 0 {
-		query += ` AND (
-			name ILIKE ?
-			OR
-		 	description ILIKE ?
-		)`
-		queries = append(
-			queries,
-			"%"+req.Keyword+"%",
-			"%"+req.Keyword+"%",
-		)
-	}
+	// Sort query
+	query += productsOrderBy(req.Sort)
 
 	// Pagination query
 	query += ` LIMIT ? OFFSET ?`
@@ -293,40 +375,168 @@ func (r *productRepository) GetProductsByShopId(ctx context.Context, req *entity
 	)
 	resp.Items = make([]entity.ProductItem, 0, req.Paginate)
 
-	query := `
+	query := localizedProducts + `
 		SELECT
 			COUNT(id) OVER() as total_data,
-			id,
-			name,
-			price,
-			stock
-		FROM products
+			` + strings.Join(productColumns(req.FieldList(), req.ExpandList()), ", ") + `
+		FROM localized_products
 		WHERE
 			deleted_at IS NULL
 			AND shop_id = ?
+			AND (? OR NOT shop_suspended)
 	`
 
 	// Search and filter query
-	queries := []interface{}{req.ShopId}
+	queries := []interface{}{req.Locale, req.ShopId, req.IncludeSuspended}
+	query, queries = productsFilter(query, queries, &req.ProductsRequest)
+
+	// Sort query
+	query += productsOrderBy(req.Sort)
+
+	// Pagination query
+	query += ` LIMIT ? OFFSET ?`
+	queries = append(
+		queries,
+		req.Paginate, req.Paginate*(req.Page-1),
+	)
+	log.Debug().Any("req", req).Msg("repository::GetProductsByShopId - req")
+	log.Debug().Any("query", query).Msg("repository::GetProductsByShopId - Failed to get products")
+	log.Debug().Any("queries", queries).Msg("repository::GetProductsByShopId - Failed to get products")
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), queries...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProductsByShopId - Failed to get products")
+		return nil, err
+	}
 
-	/// Filter by Category Ids
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.ProductItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *productRepository) GetShopsByIds(ctx context.Context, ids []string, locale string) ([]entity.ShopItem, error) {
+	var resp = make([]entity.ShopItem, 0, len(ids))
+
+	query := `
+		SELECT
+			s.id,
+			COALESCE(st.name, s.name) AS name,
+			COALESCE(st.description, s.description) AS description
+		FROM shops s
+		LEFT JOIN
+			shop_translations st ON st.shop_id = s.id AND st.locale = ?
+		WHERE
+			s.deleted_at IS NULL
+			AND s.id = ANY(?::uuid[])
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), locale, pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Any("payload", ids).Msg("repository::GetShopsByIds - Failed to get shops")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetCategoriesByIds(ctx context.Context, ids []string, locale string) ([]entity.CategoryItem, error) {
+	var resp = make([]entity.CategoryItem, 0, len(ids))
+
+	query := `
+		SELECT
+			c.id AS category_id,
+			COALESCE(ct.name, c.name) AS category_name
+		FROM categories c
+		LEFT JOIN
+			category_translations ct ON ct.category_id = c.id AND ct.locale = ?
+		WHERE
+			c.deleted_at IS NULL
+			AND c.id = ANY(?::uuid[])
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), locale, pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Any("payload", ids).Msg("repository::GetCategoriesByIds - Failed to get categories")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// localizedProducts exposes the products with their name and description in the requested locale,
+// falling back to the default locale columns when there is no translation. It takes the locale as first argument.
+// Products of deleted shops are left out even if they were not deleted with the shop.
+const localizedProducts = `
+	WITH localized_products AS (
+		SELECT
+			p.id,
+			p.shop_id,
+			p.category_id,
+			COALESCE(pt.name, p.name) AS name,
+			COALESCE(pt.description, p.description) AS description,
+			p.price,
+			p.stock,
+			p.view_count,
+			p.popularity_score,
+			p.low_stock_threshold,
+			shop_is_open(p.shop_id) AS purchasable,
+			COALESCE(s.verification_status = 'suspended', false) AS shop_suspended,
+			p.created_at,
+			p.updated_at,
+			p.deleted_at
+		FROM products p
+		JOIN
+			shops s ON s.id = p.shop_id AND s.deleted_at IS NULL
+		LEFT JOIN
+			product_translations pt ON pt.product_id = p.id AND pt.locale = ?
+	)
+`
+
+// productColumns returns the columns selected by the product listings. The fields are
+// validated against a whitelist in entity.ProductsRequest, expanded resources need their foreign keys.
+func productColumns(fields, expand []string) []string {
+	var (
+		defaults = []string{"id", "shop_id", "category_id", "name", "price", "stock", "view_count", "purchasable", "created_at", "updated_at"}
+		required = []string{"id"}
+	)
+
+	for _, e := range expand {
+		required = append(required, e+"_id")
+	}
+
+	return fieldset.Columns(fields, defaults, required...)
+}
+
+// productsFilter appends the search and filter conditions shared by the product listings.
+func productsFilter(query string, queries []interface{}, req *entity.ProductsRequest) (string, []interface{}) {
+	/// Filter by Category Ids, a parent category matches all of its descendants
+	/// and a merged category matches the category it was merged into
 	/// Example: category_ids=08362b22-f51d-40b1-a16b-49af90d561d9,3b4da768-e480-4cbb-b7fe-8b229123b50a
-	if len(req.CategoryIds) > 0 {
-		CategoryIdList := strings.Split(req.CategoryIds, ",")
-		query += ` AND category_id IN (`
-
-		for i, categoryId := range CategoryIdList {
-			query += `?`
-			if i < len(CategoryIdList)-1 {
-				query += ` , `
-			}
-
-			queries = append(
-				queries,
-				categoryId,
+	if categoryIds := req.CategoryIdList(); len(categoryIds) > 0 {
+		query += ` AND category_id IN (
+			SELECT d.id
+			FROM categories d
+			JOIN categories a ON d.path LIKE a.path || '%'
+			WHERE a.id IN (
+				SELECT COALESCE(cr.target_id, i.id)
+				FROM unnest(?::uuid[]) AS i(id)
+				LEFT JOIN category_redirects cr ON cr.source_id = i.id
 			)
-		}
-		query += `)`
+		)`
+		queries = append(queries, pq.Array(categoryIds))
+	}
+
+	/// Filter by Shop Ids
+	if shopIds := req.ShopIdList(); len(shopIds) > 0 {
+		query += ` AND shop_id = ANY(?::uuid[])`
+		queries = append(queries, pq.Array(shopIds))
 	}
 
 	/// Filter by Price Range
@@ -345,6 +555,15 @@ func (r *productRepository) GetProductsByShopId(ctx context.Context, req *entity
 		)
 	}
 
+	/// Filter by Availability
+	if req.InStock {
+		query += ` AND stock > 0`
+	}
+	if req.MinStock > 0 {
+		query += ` AND stock >= ?`
+		queries = append(queries, req.MinStock)
+	}
+
 	/// Filter by Keyword on either name or description
 	if len(req.Keyword) > 0 {
 		query += ` AND (
@@ -359,18 +578,292 @@ func (r *productRepository) GetProductsByShopId(ctx context.Context, req *entity
 		)
 	}
 
-	// Pagination query
-	query += ` LIMIT ? OFFSET ?`
-	queries = append(
-		queries,
-		req.Paginate, req.Paginate*(req.Page-1),
+	return query, queries
+}
+
+// popularityScore sums the daily views of the last 14 days, halving their weight every 3 days.
+const popularityScore = `
+	COALESCE((
+		SELECT SUM(d.views * POWER(0.5, (CURRENT_DATE - d.view_date) / 3.0))
+		FROM product_views_daily d
+		WHERE
+			d.product_id = products.id
+			AND d.view_date > CURRENT_DATE - 14
+	), 0)
+`
+
+func productsOrderBy(sort string) string {
+	switch sort {
+	case "popular":
+		return ` ORDER BY popularity_score DESC, created_at DESC`
+	case "most_viewed":
+		return ` ORDER BY view_count DESC, created_at DESC`
+	default:
+		return ` ORDER BY created_at DESC`
+	}
+}
+
+func (r *productRepository) FlushProductViews(ctx context.Context, views map[string]int) error {
+	var (
+		ids    = make([]string, 0, len(views))
+		counts = make([]int64, 0, len(views))
 	)
-	log.Debug().Any("req", req).Msg("repository::GetProductsByShopId - req")
-	log.Debug().Any("query", query).Msg("repository::GetProductsByShopId - Failed to get products")
-	log.Debug().Any("queries", queries).Msg("repository::GetProductsByShopId - Failed to get products")
-	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), queries...)
+
+	for id, n := range views {
+		ids = append(ids, id)
+		counts = append(counts, int64(n))
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
-		log.Error().Err(err).Any("payload", req).Msg("repository::GetProductsByShopId - Failed to get products")
+		log.Error().Err(err).Msg("repository::FlushProductViews - Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO product_views_daily (product_id, view_date, views)
+		SELECT v.product_id, CURRENT_DATE, v.views
+		FROM (
+			SELECT unnest(?::uuid[]) AS product_id, unnest(?::int[]) AS views
+		) v
+		JOIN products p ON p.id = v.product_id
+		ON CONFLICT (product_id, view_date)
+		DO UPDATE SET views = product_views_daily.views + EXCLUDED.views
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), pq.Array(ids), pq.Array(counts))
+	if err != nil {
+		log.Error().Err(err).Any("payload", views).Msg("repository::FlushProductViews - Failed to upsert daily views")
+		return err
+	}
+
+	query = `
+		UPDATE products
+		SET
+			view_count = products.view_count + v.views,
+			popularity_score = ` + popularityScore + `
+		FROM (
+			SELECT unnest(?::uuid[]) AS product_id, unnest(?::int[]) AS views
+		) v
+		WHERE products.id = v.product_id
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), pq.Array(ids), pq.Array(counts))
+	if err != nil {
+		log.Error().Err(err).Any("payload", views).Msg("repository::FlushProductViews - Failed to update product views")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("repository::FlushProductViews - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) RefreshPopularityScores(ctx context.Context) error {
+	query := `
+		UPDATE products
+		SET popularity_score = ` + popularityScore + `
+		WHERE
+			popularity_score > 0
+			OR id IN (
+				SELECT product_id
+				FROM product_views_daily
+				WHERE view_date > CURRENT_DATE - 14
+			)
+	`
+
+	_, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::RefreshPopularityScores - Failed to refresh popularity scores")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) GetProductViewStats(ctx context.Context, req *entity.ProductViewStatsRequest) (*entity.ProductViewStatsResponse, error) {
+	var resp = new(entity.ProductViewStatsResponse)
+
+	query := `
+		SELECT id, view_count, popularity_score
+		FROM products
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::GetProductViewStats - Product not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+		} else {
+			log.Error().Err(err).Any("payload", req).Msg("repository::GetProductViewStats - Failed to get product")
+			return nil, err
+		}
+	}
+
+	query = `
+		SELECT
+			to_char(d.day, 'YYYY-MM-DD') AS view_date,
+			COALESCE(v.views, 0) AS views
+		FROM generate_series(CURRENT_DATE - (?::int - 1), CURRENT_DATE, INTERVAL '1 day') AS d(day)
+		LEFT JOIN
+			product_views_daily v ON v.view_date = d.day::date AND v.product_id = ?
+		ORDER BY d.day
+	`
+
+	resp.Daily = make([]entity.ProductViewDaily, 0, req.Days)
+	err = r.db.SelectContext(ctx, &resp.Daily, r.db.Rebind(query), req.Days, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProductViewStats - Failed to get daily views")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// stockLevel is the stock of a product together with its effective low-stock threshold,
+// which falls back to the shop threshold when the product does not set one.
+type stockLevel struct {
+	Id        string `db:"id"`
+	ShopId    string `db:"shop_id"`
+	Stock     int    `db:"stock"`
+	Threshold int    `db:"threshold"`
+}
+
+// IsLow is false for a nil level, a product that did not exist yet.
+func (l *stockLevel) IsLow() bool {
+	return l != nil && l.Stock <= l.Threshold
+}
+
+// getStockLevel locks the product row until the transaction ends.
+func (r *productRepository) getStockLevel(ctx context.Context, tx *sqlx.Tx, productId string) (*stockLevel, error) {
+	var level = new(stockLevel)
+
+	query := `
+		SELECT
+			p.id,
+			p.shop_id,
+			p.stock,
+			COALESCE(p.low_stock_threshold, s.low_stock_threshold) AS threshold
+		FROM products p
+		JOIN
+			shops s ON p.shop_id = s.id
+		WHERE
+			p.deleted_at IS NULL
+			AND p.id = ?
+		FOR UPDATE OF p
+	`
+
+	err := tx.QueryRowxContext(ctx, r.db.Rebind(query), productId).StructScan(level)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Str("product_id", productId).Msg("repository::getStockLevel - Product not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+		} else {
+			log.Error().Err(err).Str("product_id", productId).Msg("repository::getStockLevel - Failed to get stock level")
+			return nil, err
+		}
+	}
+
+	return level, nil
+}
+
+// notifyLowStock compares the stock level before a change with the current one and,
+// when the stock has just crossed below the threshold, stores a notification for the
+// shop and emits a product.low_stock domain event. before is nil for a product that was
+// just created. Every path that changes stock (create, update, reservation, import)
+// should call it inside its own transaction.
+func (r *productRepository) notifyLowStock(ctx context.Context, tx *sqlx.Tx, productId string, before *stockLevel, source string) error {
+	after, err := r.getStockLevel(ctx, tx, productId)
+	if err != nil {
+		return err
+	}
+
+	if before.IsLow() || !after.IsLow() {
+		return nil
+	}
+
+	query := `
+		INSERT INTO stock_notifications (shop_id, product_id, stock, threshold, source)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query),
+		after.ShopId,
+		after.Id,
+		after.Stock,
+		after.Threshold,
+		source,
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", after).Msg("repository::notifyLowStock - Failed to insert stock notification")
+		return err
+	}
+
+	// a new product has no previous stock
+	var previousStock *int
+	if before != nil {
+		previousStock = &before.Stock
+	}
+
+	return outbox.Publish(ctx, tx, outbox.Event{
+		AggregateType: "product",
+		AggregateId:   after.Id,
+		EventType:     "product.low_stock",
+		Payload: map[string]any{
+			"product_id":     after.Id,
+			"shop_id":        after.ShopId,
+			"previous_stock": previousStock,
+			"stock":          after.Stock,
+			"threshold":      after.Threshold,
+			"source":         source,
+		},
+	})
+}
+
+func (r *productRepository) GetLowStockProducts(ctx context.Context, req *entity.LowStockProductsRequest) (*entity.LowStockProductsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.LowStockProductItem
+	}
+
+	var (
+		resp = new(entity.LowStockProductsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.LowStockProductItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(p.id) OVER() as total_data,
+			p.id,
+			p.name,
+			p.stock,
+			COALESCE(p.low_stock_threshold, s.low_stock_threshold) AS low_stock_threshold
+		FROM products p
+		JOIN
+			shops s ON p.shop_id = s.id
+		WHERE
+			p.deleted_at IS NULL
+			AND p.shop_id = ?
+			AND p.stock <= COALESCE(p.low_stock_threshold, s.low_stock_threshold)
+		ORDER BY p.stock ASC, p.name ASC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ShopId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetLowStockProducts - Failed to get low stock products")
 		return nil, err
 	}
 
@@ -379,10 +872,359 @@ func (r *productRepository) GetProductsByShopId(ctx context.Context, req *entity
 	}
 
 	for _, d := range data {
-		resp.Items = append(resp.Items, d.ProductItem)
+		resp.Items = append(resp.Items, d.LowStockProductItem)
 	}
 
 	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
 
 	return resp, nil
 }
+
+func (r *productRepository) GetStockNotifications(ctx context.Context, req *entity.StockNotificationsRequest) (*entity.StockNotificationsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.StockNotificationItem
+	}
+
+	var (
+		resp = new(entity.StockNotificationsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.StockNotificationItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(n.id) OVER() as total_data,
+			n.id,
+			n.product_id,
+			p.name AS product_name,
+			n.stock,
+			n.threshold,
+			n.source,
+			n.created_at,
+			n.read_at
+		FROM stock_notifications n
+		JOIN
+			products p ON n.product_id = p.id
+		WHERE
+			n.shop_id = ?
+		ORDER BY n.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ShopId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetStockNotifications - Failed to get stock notifications")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.StockNotificationItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *productRepository) GetProductTranslations(ctx context.Context, req *entity.ProductTranslationsRequest) (*entity.ProductTranslationsResponse, error) {
+	var resp = new(entity.ProductTranslationsResponse)
+	resp.Items = make([]entity.ProductTranslationItem, 0)
+
+	query := `
+		SELECT locale, name, description, updated_at
+		FROM product_translations
+		WHERE product_id = ?
+		ORDER BY locale
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.ProductId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetProductTranslations - Failed to get product translations")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) UpsertProductTranslation(ctx context.Context, req *entity.UpsertProductTranslationRequest) error {
+	query := `
+		INSERT INTO product_translations (product_id, locale, name, description)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (product_id, locale)
+		DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		req.ProductId,
+		req.Locale,
+		req.Name,
+		req.Description,
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpsertProductTranslation - Failed to upsert product translation")
+		return err
+	}
+
+	return nil
+}
+
+func (r *productRepository) DeleteProductTranslation(ctx context.Context, req *entity.DeleteProductTranslationRequest) error {
+	query := `
+		DELETE FROM product_translations
+		WHERE product_id = ? AND locale = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.ProductId, req.Locale)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteProductTranslation - Failed to delete product translation")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Terjemahan produk tidak ditemukan"))
+	}
+
+	return nil
+}
+
+// GetRelatedCandidates returns the best limit products sharing the category, the shop, a tag or
+// a similar name with the source product, skipping whatever the source shop excluded.
+// The candidates are scored in the query so that the limit keeps the best ones and not only
+// the most similar names: a shared category weighs the most among the structured signals,
+// tags count up to three, and the trigram name similarity (0..1) breaks ties between otherwise
+// equal products.
+func (r *productRepository) GetRelatedCandidates(ctx context.Context, req *entity.RelatedProductsRequest, limit int) ([]entity.RelatedCandidate, error) {
+	var resp = make([]entity.RelatedCandidate, 0, limit)
+
+	query := localizedProducts + `,
+	source AS (
+		SELECT id, shop_id, category_id, name
+		FROM products
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+	),
+	source_tags AS (
+		SELECT tag FROM product_tags WHERE product_id = ?
+	),
+	candidates AS (
+		SELECT
+			lp.id,
+			lp.shop_id,
+			lp.category_id,
+			lp.name,
+			lp.price,
+			lp.stock,
+			lp.popularity_score,
+			lp.category_id = src.category_id AS same_category,
+			lp.shop_id = src.shop_id AS same_shop,
+			(
+				SELECT COUNT(*)
+				FROM product_tags t
+				WHERE
+					t.product_id = lp.id
+					AND t.tag IN (SELECT tag FROM source_tags)
+			) AS shared_tags,
+			similarity(p.name, src.name) AS name_similarity
+		FROM localized_products lp
+		JOIN
+			products p ON p.id = lp.id
+		CROSS JOIN
+			source src
+		WHERE
+			lp.deleted_at IS NULL
+			AND NOT lp.shop_suspended
+			AND lp.id <> src.id
+			AND (
+				lp.category_id = src.category_id
+				OR lp.shop_id = src.shop_id
+				OR p.name % src.name
+				OR EXISTS (
+					SELECT 1
+					FROM product_tags t
+					WHERE
+						t.product_id = lp.id
+						AND t.tag IN (SELECT tag FROM source_tags)
+				)
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM related_exclusions e
+				WHERE
+					e.shop_id = src.shop_id
+					AND (
+						(e.type = 'product' AND e.target_id = lp.id)
+						OR (e.type = 'category' AND e.target_id = lp.category_id)
+					)
+			)
+	)
+	SELECT
+		id,
+		shop_id,
+		category_id,
+		name,
+		price,
+		stock,
+		same_category,
+		same_shop,
+		shared_tags,
+		name_similarity,
+		4 * name_similarity
+			+ CASE WHEN same_category THEN 3 ELSE 0 END
+			+ CASE WHEN same_shop THEN 1 ELSE 0 END
+			+ LEAST(shared_tags, 3) AS score
+	FROM candidates
+	ORDER BY score DESC, name_similarity DESC, popularity_score DESC
+	LIMIT ?
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), req.Locale, req.Id, req.Id, limit)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetRelatedCandidates - Failed to get related candidates")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) GetRelatedExclusions(ctx context.Context, req *entity.RelatedExclusionsRequest) (*entity.RelatedExclusionsResponse, error) {
+	var resp = new(entity.RelatedExclusionsResponse)
+	resp.Items = make([]entity.RelatedExclusionItem, 0)
+
+	query := `
+		SELECT id, type, target_id, created_at
+		FROM related_exclusions
+		WHERE shop_id = ?
+		ORDER BY created_at DESC
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetRelatedExclusions - Failed to get related exclusions")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) CreateRelatedExclusion(ctx context.Context, req *entity.CreateRelatedExclusionRequest) (*entity.CreateRelatedExclusionResponse, error) {
+	var resp = new(entity.CreateRelatedExclusionResponse)
+
+	query := `
+		INSERT INTO related_exclusions (shop_id, type, target_id)
+		VALUES (?, ?, ?)
+		ON CONFLICT (shop_id, type, target_id) DO UPDATE SET type = EXCLUDED.type
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(query), req.ShopId, req.Type, req.TargetId).Scan(&resp.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateRelatedExclusion - Failed to create related exclusion")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *productRepository) DeleteRelatedExclusion(ctx context.Context, req *entity.DeleteRelatedExclusionRequest) error {
+	query := `
+		DELETE FROM related_exclusions
+		WHERE id = ? AND shop_id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteRelatedExclusion - Failed to delete related exclusion")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Pengecualian rekomendasi tidak ditemukan"))
+	}
+
+	return nil
+}
+
+func (r *productRepository) GetQuoteProducts(ctx context.Context, ids []string, locale string) ([]entity.QuoteProduct, error) {
+	var resp = make([]entity.QuoteProduct, 0, len(ids))
+
+	query := localizedProducts + `
+		SELECT
+			lp.id,
+			lp.shop_id,
+			lp.name,
+			lp.price,
+			lp.stock,
+			lp.purchasable
+		FROM localized_products lp
+		WHERE
+			lp.deleted_at IS NULL
+			AND NOT lp.shop_suspended
+			AND lp.id = ANY(?::uuid[])
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), locale, pq.Array(ids))
+	if err != nil {
+		log.Error().Err(err).Any("ids", ids).Msg("repository::GetQuoteProducts - Failed to get products")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetFeed returns up to limit of the newest products of the shops followed by the user,
+// older than the cursor when there is one.
+func (r *productRepository) GetFeed(ctx context.Context, req *entity.FeedRequest, cursor *types.Cursor, limit int) ([]entity.FeedItem, error) {
+	var resp = make([]entity.FeedItem, 0, limit)
+
+	query := localizedProducts + `
+		SELECT
+			lp.id,
+			lp.shop_id,
+			COALESCE(st.name, s.name) AS shop_name,
+			lp.name,
+			lp.price,
+			lp.stock,
+			lp.purchasable,
+			lp.created_at
+		FROM localized_products lp
+		JOIN
+			shop_followers f ON f.shop_id = lp.shop_id AND f.user_id = ?
+		JOIN
+			shops s ON s.id = lp.shop_id AND s.deleted_at IS NULL
+		LEFT JOIN
+			shop_translations st ON st.shop_id = s.id AND st.locale = ?
+		WHERE
+			lp.deleted_at IS NULL
+			AND NOT lp.shop_suspended
+	`
+	queries := []interface{}{req.Locale, req.UserId, req.Locale}
+
+	if cursor != nil {
+		query += ` AND (lp.created_at, lp.id) < (?, ?::uuid)`
+		queries = append(queries, cursor.CreatedAt, cursor.Id)
+	}
+
+	query += ` ORDER BY lp.created_at DESC, lp.id DESC LIMIT ?`
+	queries = append(queries, limit)
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), queries...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetFeed - Failed to get feed")
+		return nil, err
+	}
+
+	return resp, nil
+}
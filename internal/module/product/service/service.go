@@ -1,20 +1,30 @@
 package service
 
 import (
+	"codebase-app/internal/infrastructure/config"
 	"codebase-app/internal/module/product/entity"
 	"codebase-app/internal/module/product/ports"
+	"codebase-app/pkg/cache"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/locale"
+	"codebase-app/pkg/types"
 	"context"
+	"time"
 )
 
 var _ ports.ProductService = &productService{}
 
 type productService struct {
-	repo ports.ProductRepository
+	repo    ports.ProductRepository
+	views   *viewBuffer
+	related *cache.TTL[string, []entity.RelatedProductItem]
 }
 
 func NewProductService(repo ports.ProductRepository) *productService {
 	return &productService{
-		repo: repo,
+		repo:    repo,
+		views:   newViewBuffer(),
+		related: cache.NewTTL[string, []entity.RelatedProductItem](time.Duration(config.Envs.Product.RelatedCacheTTL) * time.Second),
 	}
 }
 
@@ -23,7 +33,30 @@ func (s *productService) CreateProduct(ctx context.Context, req *entity.CreatePr
 }
 
 func (s *productService) GetProduct(ctx context.Context, req *entity.GetProductRequest) (*entity.GetProductResponse, error) {
-	return s.repo.GetProduct(ctx, req)
+	resp, err := s.repo.GetProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the category is always part of the product detail, only the shop needs expanding
+	for _, expand := range req.ExpandList() {
+		if expand != "shop" {
+			continue
+		}
+
+		shops, err := s.repo.GetShopsByIds(ctx, []string{resp.ShopId}, req.Locale)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(shops) > 0 {
+			resp.Shop = &shops[0]
+		}
+	}
+
+	s.views.Record(req.Id)
+
+	return resp, nil
 }
 func (s *productService) VerifyProductExists(ctx context.Context, req *entity.GetProductRequest) (*entity.GetExistingProductResponse, error) {
 	return s.repo.VerifyProductExists(ctx, req)
@@ -38,8 +71,298 @@ func (s *productService) UpdateProduct(ctx context.Context, req *entity.UpdatePr
 }
 
 func (s *productService) GetProducts(ctx context.Context, req *entity.ProductsRequest) (*entity.ProductsResponse, error) {
-	return s.repo.GetProducts(ctx, req)
+	resp, err := s.repo.GetProducts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.expandProducts(ctx, resp.Items, req.ExpandList(), req.Locale); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
 }
+
 func (s *productService) GetProductsByShopId(ctx context.Context, req *entity.ProductsByShopIdRequest) (*entity.ProductsResponse, error) {
-	return s.repo.GetProductsByShopId(ctx, req)
+	resp, err := s.repo.GetProductsByShopId(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.expandProducts(ctx, resp.Items, req.ExpandList(), req.Locale); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// expandProducts attaches the requested related resources to the items,
+// loading each resource with a single query for the whole page.
+func (s *productService) expandProducts(ctx context.Context, items []entity.ProductItem, expand []string, locale string) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	for _, e := range expand {
+		switch e {
+		case "shop":
+			shops, err := s.repo.GetShopsByIds(ctx, uniqueIds(items, func(p entity.ProductItem) string { return p.ShopId }), locale)
+			if err != nil {
+				return err
+			}
+
+			byId := make(map[string]*entity.ShopItem, len(shops))
+			for i := range shops {
+				byId[shops[i].Id] = &shops[i]
+			}
+
+			for i := range items {
+				items[i].Shop = byId[items[i].ShopId]
+			}
+		case "category":
+			categories, err := s.repo.GetCategoriesByIds(ctx, uniqueIds(items, func(p entity.ProductItem) string { return p.CategoryId }), locale)
+			if err != nil {
+				return err
+			}
+
+			byId := make(map[string]*entity.CategoryItem, len(categories))
+			for i := range categories {
+				byId[categories[i].CategoryId] = &categories[i]
+			}
+
+			for i := range items {
+				items[i].Category = byId[items[i].CategoryId]
+			}
+		}
+	}
+
+	return nil
+}
+
+func uniqueIds(items []entity.ProductItem, id func(entity.ProductItem) string) []string {
+	var (
+		seen = make(map[string]bool, len(items))
+		ids  = make([]string, 0, len(items))
+	)
+
+	for _, item := range items {
+		if v := id(item); !seen[v] {
+			seen[v] = true
+			ids = append(ids, v)
+		}
+	}
+
+	return ids
+}
+
+func (s *productService) FlushProductViews(ctx context.Context) error {
+	views := s.views.Drain()
+	if len(views) == 0 {
+		return nil
+	}
+
+	if err := s.repo.FlushProductViews(ctx, views); err != nil {
+		s.views.Restore(views)
+		return err
+	}
+
+	return nil
+}
+
+func (s *productService) RefreshPopularityScores(ctx context.Context) error {
+	return s.repo.RefreshPopularityScores(ctx)
+}
+
+func (s *productService) GetProductViewStats(ctx context.Context, req *entity.ProductViewStatsRequest) (*entity.ProductViewStatsResponse, error) {
+	resp, err := s.repo.GetProductViewStats(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.PendingViews = int64(s.views.Pending(req.Id))
+
+	return resp, nil
+}
+
+func (s *productService) GetLowStockProducts(ctx context.Context, req *entity.LowStockProductsRequest) (*entity.LowStockProductsResponse, error) {
+	return s.repo.GetLowStockProducts(ctx, req)
+}
+
+func (s *productService) GetStockNotifications(ctx context.Context, req *entity.StockNotificationsRequest) (*entity.StockNotificationsResponse, error) {
+	return s.repo.GetStockNotifications(ctx, req)
+}
+
+func (s *productService) GetProductTranslations(ctx context.Context, req *entity.ProductTranslationsRequest) (*entity.ProductTranslationsResponse, error) {
+	return s.repo.GetProductTranslations(ctx, req)
+}
+
+func (s *productService) UpsertProductTranslation(ctx context.Context, req *entity.UpsertProductTranslationRequest) error {
+	if err := locale.CheckTranslatable(req.Locale, config.Envs.App.DefaultLocale, config.Envs.App.SupportedLocales); err != nil {
+		return err
+	}
+
+	return s.repo.UpsertProductTranslation(ctx, req)
+}
+
+func (s *productService) DeleteProductTranslation(ctx context.Context, req *entity.DeleteProductTranslationRequest) error {
+	return s.repo.DeleteProductTranslation(ctx, req)
+}
+
+// relatedCacheLimit bounds the ranked items kept in the cache, which is also the largest
+// limit a client may ask for.
+const relatedCacheLimit = 50
+
+func (s *productService) GetRelatedProducts(ctx context.Context, req *entity.RelatedProductsRequest) (*entity.RelatedProductsResponse, error) {
+	var (
+		resp = new(entity.RelatedProductsResponse)
+		key  = req.Id + "|" + req.Locale
+	)
+
+	items, ok := s.related.Get(key)
+	if !ok {
+		if _, err := s.repo.VerifyProductExists(ctx, &entity.GetProductRequest{Id: req.Id}); err != nil {
+			return nil, err
+		}
+
+		candidates, err := s.repo.GetRelatedCandidates(ctx, req, relatedCacheLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		items = relatedItems(candidates)
+		s.related.Set(key, items)
+	}
+
+	resp.Items = items[:min(req.Limit, len(items))]
+
+	return resp, nil
+}
+
+// relatedItems keeps the order of the candidates, they come ranked from the repository.
+func relatedItems(candidates []entity.RelatedCandidate) []entity.RelatedProductItem {
+	items := make([]entity.RelatedProductItem, 0, len(candidates))
+
+	for _, c := range candidates {
+		items = append(items, entity.RelatedProductItem{
+			Id:         c.Id,
+			ShopId:     c.ShopId,
+			CategoryId: c.CategoryId,
+			Name:       c.Name,
+			Price:      c.Price,
+			Stock:      c.Stock,
+			Score:      c.Score,
+		})
+	}
+
+	return items
+}
+
+func (s *productService) GetRelatedExclusions(ctx context.Context, req *entity.RelatedExclusionsRequest) (*entity.RelatedExclusionsResponse, error) {
+	return s.repo.GetRelatedExclusions(ctx, req)
+}
+
+func (s *productService) CreateRelatedExclusion(ctx context.Context, req *entity.CreateRelatedExclusionRequest) (*entity.CreateRelatedExclusionResponse, error) {
+	resp, err := s.repo.CreateRelatedExclusion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cache is keyed by product, not by shop, so drop all of it
+	s.related.Clear()
+
+	return resp, nil
+}
+
+func (s *productService) DeleteRelatedExclusion(ctx context.Context, req *entity.DeleteRelatedExclusionRequest) error {
+	if err := s.repo.DeleteRelatedExclusion(ctx, req); err != nil {
+		return err
+	}
+
+	s.related.Clear()
+
+	return nil
+}
+
+// Quote prices the items as the checkout would. Items that can not be bought are kept
+// with the reason, so the buyer sees what to remove, but they are left out of the total.
+func (s *productService) Quote(ctx context.Context, req *entity.QuoteRequest) (*entity.QuoteResponse, error) {
+	var resp = new(entity.QuoteResponse)
+
+	products, err := s.repo.GetQuoteProducts(ctx, req.ProductIds(), req.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[string]entity.QuoteProduct, len(products))
+	for _, p := range products {
+		byId[p.Id] = p
+	}
+
+	resp.Items = make([]entity.QuoteItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		q := entity.QuoteItem{
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+		}
+
+		p, ok := byId[item.ProductId]
+		switch {
+		case !ok:
+			q.Reason = "Produk tidak ditemukan"
+		case !p.Purchasable:
+			q.Reason = "Toko sedang tutup"
+		case p.Stock < item.Quantity:
+			q.Reason = "Stok tidak mencukupi"
+		default:
+			q.Purchasable = true
+		}
+
+		if ok {
+			q.ShopId = p.ShopId
+			q.Name = p.Name
+			q.Price = p.Price
+			q.Subtotal = p.Price * item.Quantity
+		}
+
+		if q.Purchasable {
+			resp.Total += q.Subtotal
+		}
+
+		resp.Items = append(resp.Items, q)
+	}
+
+	return resp, nil
+}
+
+// GetFeed pages through the products of the followed shops with an opaque cursor,
+// one extra row is read to know whether there is a next page.
+func (s *productService) GetFeed(ctx context.Context, req *entity.FeedRequest) (*entity.FeedResponse, error) {
+	var (
+		resp   = new(entity.FeedResponse)
+		cursor *types.Cursor
+		err    error
+	)
+
+	if req.Cursor != "" {
+		cursor, err = types.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("cursor", "cursor tidak valid."))
+		}
+	}
+
+	items, err := s.repo.GetFeed(ctx, req, cursor, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Meta.Limit = req.Limit
+	if len(items) > req.Limit {
+		items = items[:req.Limit]
+		last := items[len(items)-1]
+		next := types.Cursor{CreatedAt: last.CreatedAt, Id: last.Id}.Encode()
+		resp.Meta.NextCursor = &next
+	}
+	resp.Items = items
+
+	return resp, nil
 }
@@ -0,0 +1,51 @@
+package service
+
+import "sync"
+
+// viewBuffer counts product views in memory so GetProduct does not write to the
+// database on every request. The counts are drained and persisted in batches.
+type viewBuffer struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newViewBuffer() *viewBuffer {
+	return &viewBuffer{
+		counts: make(map[string]int),
+	}
+}
+
+func (b *viewBuffer) Record(productId string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.counts[productId]++
+}
+
+func (b *viewBuffer) Pending(productId string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.counts[productId]
+}
+
+// Drain returns the buffered counts and resets the buffer.
+func (b *viewBuffer) Drain() map[string]int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	counts := b.counts
+	b.counts = make(map[string]int, len(counts))
+
+	return counts
+}
+
+// Restore puts counts back into the buffer, used when a flush fails.
+func (b *viewBuffer) Restore(counts map[string]int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, n := range counts {
+		b.counts[id] += n
+	}
+}
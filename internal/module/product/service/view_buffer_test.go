@@ -0,0 +1,73 @@
+package service
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewBufferDrainWhileRecording(t *testing.T) {
+	const (
+		writers = 8
+		views   = 1000
+	)
+
+	var (
+		b       = newViewBuffer()
+		wg      sync.WaitGroup
+		done    = make(chan struct{})
+		drained = make(map[string]int)
+	)
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < views; j++ {
+				b.Record("a")
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// drain as a flush would until every writer is done, no view may be lost nor counted twice
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+
+		for id, n := range b.Drain() {
+			drained[id] += n
+		}
+	}
+
+	assert.Equal(t, writers*views, drained["a"])
+	assert.Equal(t, 0, b.Pending("a"))
+}
+
+func TestViewBufferRestore(t *testing.T) {
+	b := newViewBuffer()
+	b.Record("a")
+	b.Record("a")
+	b.Record("b")
+
+	counts := b.Drain()
+	assert.Equal(t, 0, b.Pending("a"))
+
+	// views recorded while the failed flush was running are kept next to the restored ones
+	b.Record("a")
+	b.Record("c")
+	b.Restore(counts)
+
+	assert.Equal(t, 3, b.Pending("a"))
+	assert.Equal(t, 1, b.Pending("b"))
+	assert.Equal(t, 1, b.Pending("c"))
+	assert.Equal(t, map[string]int{"a": 3, "b": 1, "c": 1}, b.Drain())
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+type CreateQuestionRequest struct {
+	ProductId string `validate:"uuid" db:"product_id"`
+	UserId    string `validate:"uuid" db:"user_id"`
+
+	Question string `json:"question" validate:"required,max=1000" db:"question"`
+}
+
+type CreateQuestionResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type GetQuestionRequest struct {
+	Id string `validate:"uuid" db:"id"`
+}
+
+type GetExistingQuestionResponse struct {
+	Id        string `json:"id" db:"id"`
+	ProductId string `json:"product_id" db:"product_id"`
+	ShopId    string `json:"shop_id" db:"shop_id"`
+	UserId    string `json:"user_id" db:"user_id"` // shop owner
+}
+
+type AnswerQuestionRequest struct {
+	Id         string `validate:"uuid" db:"id"`
+	AnsweredBy string `validate:"uuid" db:"answered_by"`
+
+	Answer string `json:"answer" validate:"required,max=2000" db:"answer"`
+}
+
+type AnswerQuestionResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type QuestionsRequest struct {
+	ProductId string `validate:"uuid" db:"product_id"`
+	Page      int    `query:"page" validate:"required"`
+	Paginate  int    `query:"paginate" validate:"required"`
+}
+
+func (r *QuestionsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type QuestionItem struct {
+	Id         string     `json:"id" db:"id"`
+	UserId     string     `json:"user_id" db:"user_id"`
+	Question   string     `json:"question" db:"question"`
+	Answer     *string    `json:"answer" db:"answer"`
+	AnsweredAt *time.Time `json:"answered_at" db:"answered_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+type QuestionsResponse struct {
+	Items []QuestionItem `json:"items"`
+	Meta  types.Meta     `json:"meta"`
+}
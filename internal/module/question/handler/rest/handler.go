@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/question/entity"
+	"codebase-app/internal/module/question/ports"
+	"codebase-app/internal/module/question/repository"
+	"codebase-app/internal/module/question/service"
+	shopEntity "codebase-app/internal/module/shop/entity"
+	shopPorts "codebase-app/internal/module/shop/ports"
+	shopRepository "codebase-app/internal/module/shop/repository"
+	shopService "codebase-app/internal/module/shop/service"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type questionHandler struct {
+	service     ports.QuestionService
+	shopService shopPorts.ShopService
+}
+
+func NewQuestionHandler() *questionHandler {
+	var (
+		handler  = new(questionHandler)
+		repo     = repository.NewQuestionRepository(adapter.Adapters.ShopeefunPostgres)
+		service  = service.NewQuestionService(repo)
+		shopRepo = shopRepository.NewShopRepository(adapter.Adapters.ShopeefunPostgres)
+	)
+	handler.service = service
+	handler.shopService = shopService.NewShopService(shopRepo)
+
+	return handler
+}
+
+func (h *questionHandler) Register(router fiber.Router) {
+	router.Get("/products/:product_id/questions", h.GetQuestions)
+	router.Post("/products/:product_id/questions", middleware.UserIdHeader, h.CreateQuestion)
+	router.Post("/questions/:id/answer", middleware.UserIdHeader, h.AnswerQuestion)
+}
+
+func (h *questionHandler) CreateQuestion(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateQuestionRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateQuestion - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ProductId = c.Params("product_id")
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateQuestion - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateQuestion(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *questionHandler) AnswerQuestion(c *fiber.Ctx) error {
+	var (
+		req            = new(entity.AnswerQuestionRequest)
+		reqGetQuestion = new(entity.GetQuestionRequest)
+		ctx            = c.Context()
+		v              = adapter.Adapters.Validator
+		l              = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::AnswerQuestion - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+	req.AnsweredBy = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::AnswerQuestion - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	reqGetQuestion.Id = req.Id
+	respExistingQuestion, err := h.service.VerifyQuestionExists(ctx, reqGetQuestion)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.shopService.CheckPermission(ctx, &shopEntity.CheckPermissionRequest{
+		ShopId:     respExistingQuestion.ShopId,
+		UserId:     l.UserId,
+		Permission: shopEntity.PermQuestionsAnswer,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.AnswerQuestion(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *questionHandler) GetQuestions(c *fiber.Ctx) error {
+	var (
+		req = new(entity.QuestionsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetQuestions - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ProductId = c.Params("product_id")
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetQuestions - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetQuestions(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"codebase-app/internal/module/question/entity"
+	"context"
+)
+
+type QuestionRepository interface {
+	CreateQuestion(ctx context.Context, req *entity.CreateQuestionRequest) (*entity.CreateQuestionResponse, error)
+	VerifyQuestionExists(ctx context.Context, req *entity.GetQuestionRequest) (*entity.GetExistingQuestionResponse, error)
+	AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.AnswerQuestionResponse, error)
+	GetQuestions(ctx context.Context, req *entity.QuestionsRequest) (*entity.QuestionsResponse, error)
+}
+
+type QuestionService interface {
+	CreateQuestion(ctx context.Context, req *entity.CreateQuestionRequest) (*entity.CreateQuestionResponse, error)
+	VerifyQuestionExists(ctx context.Context, req *entity.GetQuestionRequest) (*entity.GetExistingQuestionResponse, error)
+	AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.AnswerQuestionResponse, error)
+	GetQuestions(ctx context.Context, req *entity.QuestionsRequest) (*entity.QuestionsResponse, error)
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"codebase-app/internal/module/question/entity"
+	"codebase-app/internal/module/question/ports"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ ports.QuestionRepository = &questionRepository{}
+
+type questionRepository struct {
+	db *sqlx.DB
+}
+
+func NewQuestionRepository(db *sqlx.DB) *questionRepository {
+	return &questionRepository{
+		db: db,
+	}
+}
+
+func (r *questionRepository) CreateQuestion(ctx context.Context, req *entity.CreateQuestionRequest) (*entity.CreateQuestionResponse, error) {
+	var resp = new(entity.CreateQuestionResponse)
+
+	query := `
+		INSERT INTO product_questions (product_id, user_id, question)
+		SELECT p.id, ?, ?
+		FROM products p
+		JOIN
+			shops s ON p.shop_id = s.id
+		WHERE
+			p.deleted_at IS NULL
+			AND s.deleted_at IS NULL
+			AND p.id = ?
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, r.db.Rebind(query),
+		req.UserId,
+		req.Question,
+		req.ProductId,
+	).Scan(&resp.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Warn().Err(err).Any("payload", req).Msg("repository::CreateQuestion - Product not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Produk tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateQuestion - Failed to create question")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *questionRepository) VerifyQuestionExists(ctx context.Context, req *entity.GetQuestionRequest) (*entity.GetExistingQuestionResponse, error) {
+	var resp = new(entity.GetExistingQuestionResponse)
+
+	query := `
+		SELECT
+			q.id,
+			q.product_id,
+			p.shop_id,
+			s.user_id
+		FROM product_questions q
+		JOIN
+			products p ON q.product_id = p.id
+		JOIN
+			shops s ON p.shop_id = s.id
+		WHERE
+			q.deleted_at IS NULL
+			AND p.deleted_at IS NULL
+			AND s.deleted_at IS NULL
+			AND q.id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::VerifyQuestionExists - Question not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Pertanyaan tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::VerifyQuestionExists - Failed to get question")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AnswerQuestion answers a question once, an answered question can not be answered again.
+func (r *questionRepository) AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.AnswerQuestionResponse, error) {
+	var resp = new(entity.AnswerQuestionResponse)
+
+	query := `
+		UPDATE product_questions
+		SET
+			answer = ?,
+			answered_by = ?,
+			answered_at = NOW(),
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND answered_at IS NULL
+			AND id = ?
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Answer,
+		req.AnsweredBy,
+		req.Id,
+	).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, r.answerConflict(ctx, req.Id)
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::AnswerQuestion - Failed to answer question")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// answerConflict tells why a question could not be answered: it is gone or already answered.
+func (r *questionRepository) answerConflict(ctx context.Context, id string) error {
+	var exists bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM product_questions
+			WHERE
+				deleted_at IS NULL
+				AND id = ?
+		)
+	`
+
+	if err := r.db.GetContext(ctx, &exists, r.db.Rebind(query), id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repository::AnswerQuestion - Failed to check question")
+		return err
+	}
+
+	if !exists {
+		log.Error().Str("id", id).Msg("repository::AnswerQuestion - Question not found")
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Pertanyaan tidak ditemukan"))
+	}
+
+	return errmsg.NewCustomErrors(409, errmsg.WithMessage("Pertanyaan sudah dijawab"))
+}
+
+func (r *questionRepository) GetQuestions(ctx context.Context, req *entity.QuestionsRequest) (*entity.QuestionsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.QuestionItem
+	}
+
+	var (
+		resp = new(entity.QuestionsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.QuestionItem, 0, req.Paginate)
+
+	// answered questions first, newest answers on top, then the newest unanswered questions
+	query := `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			user_id,
+			question,
+			answer,
+			answered_at,
+			created_at
+		FROM product_questions
+		WHERE
+			deleted_at IS NULL
+			AND product_id = ?
+		ORDER BY
+			answered_at IS NULL,
+			answered_at DESC,
+			created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ProductId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetQuestions - Failed to get questions")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.QuestionItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"codebase-app/internal/module/question/entity"
+	"codebase-app/internal/module/question/ports"
+	"context"
+)
+
+var _ ports.QuestionService = &questionService{}
+
+type questionService struct {
+	repo ports.QuestionRepository
+}
+
+func NewQuestionService(repo ports.QuestionRepository) *questionService {
+	return &questionService{
+		repo: repo,
+	}
+}
+
+func (s *questionService) CreateQuestion(ctx context.Context, req *entity.CreateQuestionRequest) (*entity.CreateQuestionResponse, error) {
+	return s.repo.CreateQuestion(ctx, req)
+}
+
+func (s *questionService) VerifyQuestionExists(ctx context.Context, req *entity.GetQuestionRequest) (*entity.GetExistingQuestionResponse, error) {
+	return s.repo.VerifyQuestionExists(ctx, req)
+}
+
+func (s *questionService) AnswerQuestion(ctx context.Context, req *entity.AnswerQuestionRequest) (*entity.AnswerQuestionResponse, error) {
+	return s.repo.AnswerQuestion(ctx, req)
+}
+
+func (s *questionService) GetQuestions(ctx context.Context, req *entity.QuestionsRequest) (*entity.QuestionsResponse, error) {
+	return s.repo.GetQuestions(ctx, req)
+}
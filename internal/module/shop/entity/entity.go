@@ -1,6 +1,11 @@
 package entity
 
-import "codebase-app/pkg/types"
+import (
+	"codebase-app/pkg/fieldset"
+	"codebase-app/pkg/types"
+	"mime/multipart"
+	"time"
+)
 
 type CreateShopRequest struct {
 	UserId string `validate:"uuid" db:"user_id"`
@@ -16,6 +21,9 @@ type CreateShopResponse struct {
 
 type GetShopRequest struct {
 	Id string `validate:"uuid" db:"id"`
+
+	Locale string
+	UserId string // optional viewer, members still see a suspended shop and its verification reason
 }
 
 type GetExistingShopResponse struct {
@@ -24,30 +32,112 @@ type GetExistingShopResponse struct {
 }
 
 type GetShopResponse struct {
-	Name        string `json:"name" db:"name"`
-	Description string `json:"description" db:"description"`
-	Terms       string `json:"terms" db:"terms"`
+	Name         string `json:"name" db:"name"`
+	Description  string `json:"description" db:"description"`
+	Terms        string `json:"terms" db:"terms"`
+	TermsVersion int    `json:"terms_version" db:"terms_version"`
+	// TranslatedTerms is a reading aid in the requested locale, it is not versioned and
+	// buyers always accept Terms.
+	TranslatedTerms *string       `json:"translated_terms" db:"translated_terms"`
+	LogoUrl         *string       `json:"logo_url" db:"logo_url"`
+	BannerUrl       *string       `json:"banner_url" db:"banner_url"`
+	AccentColor     *string       `json:"accent_color" db:"accent_color"`
+	SocialLinks     types.JSONMap `json:"social_links" db:"social_links"`
+	Address         *string       `json:"address" db:"address"`
+	City            *string       `json:"city" db:"city"`
+	Province        *string       `json:"province" db:"province"`
+	PostalCode      *string       `json:"postal_code" db:"postal_code"`
+	Location        *ShopLocation `json:"location" db:"-"`
+	Timezone        string        `json:"timezone" db:"timezone"`
+	IsOpen          bool          `json:"is_open" db:"is_open"`
+	Vacation        *ShopVacation `json:"vacation" db:"-"`
+
+	VerificationStatus *string `json:"verification_status" db:"verification_status"`
+	VerificationReason *string `json:"verification_reason,omitempty" db:"verification_reason"`
+
+	FollowerCount int  `json:"follower_count" db:"follower_count"`
+	Following     bool `json:"following" db:"following"` // false for anonymous viewers
+
+	OperatingHours []OperatingHourItem `json:"operating_hours" db:"-"`
+
+	Point           *types.Point `json:"-" db:"location"`
+	VacationMode    bool         `json:"-" db:"vacation_mode"`
+	VacationStart   *string      `json:"-" db:"vacation_start"`
+	VacationEnd     *string      `json:"-" db:"vacation_end"`
+	VacationMessage *string      `json:"-" db:"vacation_message"`
+}
+
+func (r *GetShopResponse) IsSuspended() bool {
+	return r.VerificationStatus != nil && *r.VerificationStatus == VerificationSuspended
+}
+
+type ShopVacation struct {
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+	Message   *string `json:"message"`
+}
+
+// ShopLocation is the JSON form of a types.Point, which holds longitude first.
+type ShopLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+func NewShopLocation(p *types.Point) *ShopLocation {
+	if p == nil {
+		return nil
+	}
+
+	return &ShopLocation{Latitude: p[1], Longitude: p[0]}
 }
 
 type DeleteShopRequest struct {
-	Id string `validate:"uuid" db:"id"`
+	Id     string `validate:"uuid" db:"id"`
+	UserId string `validate:"uuid" db:"user_id"`
+
+	// DryRun only reports what the deletion would affect.
+	DryRun bool `query:"dry_run"`
+}
+
+// DeleteShopPreviewResponse counts what deleting the shop soft-deletes or cancels.
+type DeleteShopPreviewResponse struct {
+	ShopId             string `json:"shop_id" db:"shop_id"`
+	Products           int    `json:"products" db:"products"`
+	PendingInvitations int    `json:"pending_invitations" db:"pending_invitations"`
+	PendingTransfers   int    `json:"pending_transfers" db:"pending_transfers"`
+	Members            int    `json:"members" db:"members"`
+	Followers          int    `json:"followers" db:"followers"`
 }
 
 type UpdateShopRequest struct {
 	Id          string `params:"id" validate:"uuid" db:"id"`
+	UserId      string `validate:"uuid" db:"user_id"`
 	Name        string `json:"name" validate:"required" db:"name"`
 	Description string `json:"description" validate:"required" db:"description"`
 	Terms       string `json:"terms" validate:"required" db:"terms"`
+
+	// LowStockThreshold applies to products without their own threshold; left unchanged when omitted.
+	LowStockThreshold *int `json:"low_stock_threshold" validate:"omitempty,gte=0" db:"low_stock_threshold"`
 }
 
 type UpdateShopResponse struct {
-	Id string `json:"id" db:"id"`
+	Id           string `json:"id" db:"id"`
+	TermsVersion int    `json:"terms_version" db:"terms_version"`
 }
 
 type ShopsRequest struct {
 	UserId   string `prop:"user_id" validate:"uuid"`
 	Page     int    `query:"page" validate:"required"`
 	Paginate int    `query:"paginate" validate:"required"`
+
+	/// Example: fields=id,name
+	Fields string `query:"fields" validate:"omitempty,csv_oneof=id name description verification_status verification_reason created_at updated_at"`
+
+	Locale string `query:"-"`
+}
+
+func (r *ShopsRequest) FieldList() []string {
+	return fieldset.Parse(r.Fields)
 }
 
 func (r *ShopsRequest) SetDefault() {
@@ -61,11 +151,257 @@ func (r *ShopsRequest) SetDefault() {
 }
 
 type ShopItem struct {
-	Id   string `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	Id                 string    `json:"id" db:"id"`
+	Name               string    `json:"name" db:"name"`
+	Description        string    `json:"description" db:"description"`
+	VerificationStatus *string   `json:"verification_status" db:"verification_status"`
+	VerificationReason *string   `json:"verification_reason" db:"verification_reason"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
 }
 
 type ShopsResponse struct {
 	Items []ShopItem `json:"items"`
 	Meta  types.Meta `json:"meta"`
 }
+
+type ShopTranslationsRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+}
+
+type ShopTranslationItem struct {
+	Locale      string    `json:"locale" db:"locale"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	Terms       *string   `json:"terms" db:"terms"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type ShopTranslationsResponse struct {
+	Items []ShopTranslationItem `json:"items"`
+}
+
+type UpsertShopTranslationRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	Locale string `params:"locale" validate:"required,bcp47_language_tag" db:"locale"`
+
+	Name        string  `json:"name" validate:"required,max=255" db:"name"`
+	Description string  `json:"description" validate:"required,max=255" db:"description"`
+	Terms       *string `json:"terms" db:"terms"`
+}
+
+type DeleteShopTranslationRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	Locale string `params:"locale" validate:"required,bcp47_language_tag" db:"locale"`
+}
+
+type UploadShopAssetRequest struct {
+	Id string `validate:"uuid" db:"id"`
+
+	File *multipart.FileHeader `form:"file" validate:"required"`
+}
+
+type UpdateShopAssetRequest struct {
+	Id    string `validate:"uuid" db:"id"`
+	Asset string `validate:"oneof=logo banner"`
+	Url   string `validate:"required,url"`
+}
+
+type UpdateShopAssetResponse struct {
+	Url string `json:"url"`
+}
+
+type UpdateShopBrandingRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+
+	// AccentColor is left unchanged when omitted.
+	AccentColor *string `json:"accent_color" validate:"omitempty,hexcolor" db:"accent_color"`
+
+	// SocialLinks replace the current links, they are left unchanged when omitted.
+	SocialLinks types.JSONMap `json:"social_links" validate:"omitempty,max=10,dive,keys,oneof=instagram facebook tiktok x youtube website,endkeys,url" db:"social_links"`
+}
+
+type UpdateShopBrandingResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type UpdateShopLocationRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+
+	Latitude   *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude  *float64 `json:"longitude" validate:"required,longitude"`
+	Address    string   `json:"address" validate:"required,max=255" db:"address"`
+	City       string   `json:"city" validate:"required,max=100" db:"city"`
+	Province   string   `json:"province" validate:"required,max=100" db:"province"`
+	PostalCode string   `json:"postal_code" validate:"required,numeric,max=10" db:"postal_code"`
+}
+
+func (r *UpdateShopLocationRequest) Point() types.Point {
+	return types.Point{*r.Longitude, *r.Latitude}
+}
+
+type UpdateShopLocationResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type SearchShopsRequest struct {
+	Page     int `query:"page" validate:"required"`
+	Paginate int `query:"paginate" validate:"required"`
+
+	// Filter, min_rating and sort=rating read shops.rating_average, which stays at zero
+	// until shop reviews are recorded
+	Keyword   string  `query:"keyword" validate:"omitempty,max=100"`
+	City      string  `query:"city" validate:"omitempty,max=100"`
+	Province  string  `query:"province" validate:"omitempty,max=100"`
+	Verified  bool    `query:"verified"`
+	MinRating float64 `query:"min_rating" validate:"gte=0,lte=5"`
+
+	/// Shops selling at least one product in any of the categories
+	/// Example: category_ids=08362b22-f51d-40b1-a16b-49af90d561d9,3b4da768-e480-4cbb-b7fe-8b229123b50a
+	CategoryIds string `query:"category_ids" validate:"omitempty,uuid_csv"`
+
+	// Location, lat and lng go together and limit the result to radius_km around them
+	Latitude  *float64 `query:"lat" validate:"omitempty,latitude"`
+	Longitude *float64 `query:"lng" validate:"omitempty,longitude"`
+	RadiusKm  float64  `query:"radius_km" validate:"gte=0,lte=100"`
+
+	// Sort
+	Sort string `query:"sort" validate:"omitempty,oneof=newest name rating distance"`
+
+	Locale string `query:"-"`
+}
+
+func (r *SearchShopsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+
+	if r.HasPoint() && r.RadiusKm <= 0 {
+		r.RadiusKm = 10
+	}
+
+	if r.Sort == "" {
+		r.Sort = "newest"
+	}
+}
+
+func (r *SearchShopsRequest) HasPoint() bool {
+	return r.Latitude != nil && r.Longitude != nil
+}
+
+func (r *SearchShopsRequest) Point() types.Point {
+	return types.Point{*r.Longitude, *r.Latitude}
+}
+
+func (r *SearchShopsRequest) CategoryIdList() []string {
+	return fieldset.Parse(r.CategoryIds)
+}
+
+type SearchShopItem struct {
+	Id            string        `json:"id" db:"id"`
+	Name          string        `json:"name" db:"name"`
+	Description   string        `json:"description" db:"description"`
+	LogoUrl       *string       `json:"logo_url" db:"logo_url"`
+	City          *string       `json:"city" db:"city"`
+	Province      *string       `json:"province" db:"province"`
+	Verified      bool          `json:"verified" db:"verified"`
+	RatingAverage float64       `json:"rating_average" db:"rating_average"`
+	RatingCount   int           `json:"rating_count" db:"rating_count"`
+	DistanceKm    *float64      `json:"distance_km,omitempty" db:"distance_km"`
+	Location      *ShopLocation `json:"location" db:"-"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+
+	Point *types.Point `json:"-" db:"location"`
+}
+
+type SearchShopsResponse struct {
+	Items []SearchShopItem `json:"items"`
+	Meta  types.Meta       `json:"meta"`
+}
+
+type NearbyShopsRequest struct {
+	Latitude  *float64 `query:"lat" validate:"required,latitude"`
+	Longitude *float64 `query:"lng" validate:"required,longitude"`
+	RadiusKm  float64  `query:"radius_km" validate:"gt=0,lte=100"`
+	Page      int      `query:"page" validate:"required"`
+	Paginate  int      `query:"paginate" validate:"required"`
+
+	Locale string `query:"-"`
+}
+
+func (r *NearbyShopsRequest) SetDefault() {
+	if r.RadiusKm <= 0 {
+		r.RadiusKm = 10
+	}
+
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+func (r *NearbyShopsRequest) Point() types.Point {
+	return types.Point{*r.Longitude, *r.Latitude}
+}
+
+type NearbyShopItem struct {
+	Id          string        `json:"id" db:"id"`
+	Name        string        `json:"name" db:"name"`
+	Description string        `json:"description" db:"description"`
+	Address     *string       `json:"address" db:"address"`
+	City        *string       `json:"city" db:"city"`
+	Verified    bool          `json:"verified" db:"verified"`
+	DistanceKm  float64       `json:"distance_km" db:"distance_km"`
+	Location    *ShopLocation `json:"location" db:"-"`
+
+	Point *types.Point `json:"-" db:"location"`
+}
+
+type NearbyShopsResponse struct {
+	Items []NearbyShopItem `json:"items"`
+	Meta  types.Meta       `json:"meta"`
+}
+
+// OperatingHourItem closes the next day when ClosesAt is before OpensAt, ex: 20:00 - 02:00.
+type OperatingHourItem struct {
+	Weekday  int    `json:"weekday" validate:"min=0,max=6" db:"weekday"` // 0 is sunday
+	OpensAt  string `json:"opens_at" validate:"required,datetime=15:04" db:"opens_at"`
+	ClosesAt string `json:"closes_at" validate:"required,datetime=15:04" db:"closes_at"`
+}
+
+type UpdateOperatingHoursRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+
+	Timezone string `json:"timezone" validate:"required,timezone" db:"timezone"`
+
+	// Hours replace the current hours, one item per weekday; no hours means always open.
+	Hours []OperatingHourItem `json:"hours" validate:"max=7,dive"`
+}
+
+type UpdateOperatingHoursResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type UpdateShopVacationRequest struct {
+	Id string `params:"id" validate:"uuid" db:"id"`
+
+	// StartDate is the first closed day, the vacation starts right away when omitted.
+	StartDate *string `json:"start_date" validate:"omitempty,datetime=2006-01-02" db:"vacation_start"`
+	EndDate   string  `json:"end_date" validate:"required,datetime=2006-01-02" db:"vacation_end"`
+	Message   *string `json:"message" validate:"omitempty,max=255" db:"vacation_message"`
+}
+
+type UpdateShopVacationResponse struct {
+	Id string `json:"id" db:"id"`
+}
+
+type EndShopVacationRequest struct {
+	Id string `validate:"uuid" db:"id"`
+}
@@ -0,0 +1,6 @@
+package entity
+
+type FollowShopRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	UserId string `validate:"uuid" db:"user_id"`
+}
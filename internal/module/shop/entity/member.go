@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+const (
+	RoleOwner          = "owner"
+	RoleManager        = "manager"
+	RoleInventoryClerk = "inventory_clerk"
+	RoleViewer         = "viewer"
+)
+
+// Permission is something a shop member may do, granted through the member role.
+type Permission string
+
+const (
+	PermShopManage      Permission = "shop.manage"      // profile, branding, location, hours, vacation and exclusions
+	PermShopDelete      Permission = "shop.delete"      // delete the shop
+	PermShopTransfer    Permission = "shop.transfer"    // hand the shop over to another user
+	PermMembersManage   Permission = "members.manage"   // invite, change and remove members
+	PermProductsWrite   Permission = "products.write"   // create, update and delete products
+	PermProductsRead    Permission = "products.read"    // stats, stock alerts and other seller-only reads
+	PermQuestionsAnswer Permission = "questions.answer" // answer buyer questions
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleOwner:          {PermShopManage, PermShopDelete, PermShopTransfer, PermMembersManage, PermProductsWrite, PermProductsRead, PermQuestionsAnswer},
+	RoleManager:        {PermShopManage, PermProductsWrite, PermProductsRead, PermQuestionsAnswer},
+	RoleInventoryClerk: {PermProductsWrite, PermProductsRead},
+	RoleViewer:         {PermProductsRead},
+}
+
+// RoleAllows reports whether the role is granted the permission.
+func RoleAllows(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+
+	return false
+}
+
+type CheckPermissionRequest struct {
+	ShopId     string
+	UserId     string
+	Permission Permission
+}
+
+type GetMemberRoleResponse struct {
+	ShopId string  `db:"shop_id"`
+	Role   *string `db:"role"` // nil when the user is not a member
+}
+
+type ShopMembersRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+}
+
+type ShopMemberItem struct {
+	UserId    string    `json:"user_id" db:"user_id"`
+	Role      string    `json:"role" db:"role"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type ShopMembersResponse struct {
+	Items []ShopMemberItem `json:"items"`
+}
+
+type UpdateShopMemberRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	UserId string `params:"user_id" validate:"uuid" db:"user_id"`
+
+	Role string `json:"role" validate:"required,oneof=manager inventory_clerk viewer" db:"role"`
+}
+
+type DeleteShopMemberRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	UserId string `validate:"uuid" db:"user_id"`
+}
+
+type CreateInvitationRequest struct {
+	ShopId    string `validate:"uuid" db:"shop_id"`
+	InvitedBy string `validate:"uuid" db:"invited_by"`
+
+	UserId string `json:"user_id" validate:"uuid" db:"user_id"`
+	Role   string `json:"role" validate:"required,oneof=manager inventory_clerk viewer" db:"role"`
+}
+
+type CreateInvitationResponse struct {
+	Id        string    `json:"id" db:"id"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
+
+type ShopInvitationsRequest struct {
+	ShopId   string `validate:"uuid" db:"shop_id"`
+	Status   string `query:"status" validate:"omitempty,oneof=pending accepted declined cancelled"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *ShopInvitationsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type MyInvitationsRequest struct {
+	UserId   string `prop:"user_id" validate:"uuid"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *MyInvitationsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type InvitationItem struct {
+	Id        string    `json:"id" db:"id"`
+	ShopId    string    `json:"shop_id" db:"shop_id"`
+	ShopName  string    `json:"shop_name" db:"shop_name"`
+	UserId    string    `json:"user_id" db:"user_id"`
+	Role      string    `json:"role" db:"role"`
+	Status    string    `json:"status" db:"status"`
+	InvitedBy string    `json:"invited_by" db:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type InvitationsResponse struct {
+	Items []InvitationItem `json:"items"`
+	Meta  types.Meta       `json:"meta"`
+}
+
+type CancelInvitationRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	Id     string `validate:"uuid" db:"id"`
+}
+
+type RespondInvitationRequest struct {
+	Id     string `validate:"uuid" db:"id"`
+	UserId string `validate:"uuid" db:"user_id"`
+	Accept bool
+}
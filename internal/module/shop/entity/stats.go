@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+type ShopStatsRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+}
+
+// ShopStatsResponse comes from the shop_stats materialized view, so the product
+// figures lag behind by up to the refresh interval. RefreshedAt is nil until the
+// first refresh after the shop was created.
+type ShopStatsResponse struct {
+	ShopId        string             `json:"shop_id" db:"shop_id"`
+	Products      ShopProductsStats  `json:"products" db:"-"`
+	Inventory     ShopInventoryStats `json:"inventory" db:"-"`
+	TotalViews    int64              `json:"total_views" db:"total_views"`
+	RatingAverage float64            `json:"rating_average" db:"rating_average"`
+	RatingCount   int                `json:"rating_count" db:"rating_count"`
+	RefreshedAt   *time.Time         `json:"refreshed_at" db:"refreshed_at"`
+}
+
+type ShopProductsStats struct {
+	Total      int `json:"total" db:"product_count"`
+	InStock    int `json:"in_stock" db:"in_stock_count"`
+	LowStock   int `json:"low_stock" db:"low_stock_count"`
+	OutOfStock int `json:"out_of_stock" db:"out_of_stock_count"`
+	Deleted    int `json:"deleted" db:"deleted_count"`
+}
+
+type ShopInventoryStats struct {
+	Units int64 `json:"units" db:"inventory_units"`
+	Value int64 `json:"value" db:"inventory_value"`
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+type TermsVersionsRequest struct {
+	ShopId   string `validate:"uuid" db:"shop_id"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *TermsVersionsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type TermsVersionItem struct {
+	Version   int       `json:"version" db:"version"`
+	Terms     string    `json:"terms" db:"terms"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type TermsVersionsResponse struct {
+	Items []TermsVersionItem `json:"items"`
+	Meta  types.Meta         `json:"meta"`
+}
+
+type GetTermsVersionRequest struct {
+	ShopId  string `validate:"uuid" db:"shop_id"`
+	Version int    `params:"version" validate:"gte=1" db:"version"`
+}
+
+type RecordTermsAcceptanceRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	UserId string `validate:"uuid" db:"user_id"`
+
+	Version   int     `json:"version" validate:"required,gte=1" db:"version"`
+	Reference *string `json:"reference" validate:"omitempty,max=100" db:"reference"`
+}
+
+type RecordTermsAcceptanceResponse struct {
+	Id         string    `json:"id" db:"id"`
+	AcceptedAt time.Time `json:"accepted_at" db:"accepted_at"`
+}
+
+type TermsAcceptancesRequest struct {
+	ShopId   string `validate:"uuid" db:"shop_id"`
+	UserId   string `query:"user_id" validate:"omitempty,uuid"`
+	Version  int    `query:"version" validate:"gte=0"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *TermsAcceptancesRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type TermsAcceptanceItem struct {
+	Id         string    `json:"id" db:"id"`
+	Version    int       `json:"version" db:"version"`
+	UserId     string    `json:"user_id" db:"user_id"`
+	Reference  *string   `json:"reference" db:"reference"`
+	AcceptedAt time.Time `json:"accepted_at" db:"accepted_at"`
+}
+
+type TermsAcceptancesResponse struct {
+	Items []TermsAcceptanceItem `json:"items"`
+	Meta  types.Meta            `json:"meta"`
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+type CreateTransferRequest struct {
+	ShopId     string    `validate:"uuid" db:"shop_id"`
+	FromUserId string    `validate:"uuid" db:"from_user_id"`
+	ExpiresAt  time.Time `db:"expires_at"`
+
+	ToUserId string `json:"to_user_id" validate:"uuid" db:"to_user_id"`
+	// KeepPreviousOwner keeps the current owner on as manager, they leave the shop otherwise.
+	KeepPreviousOwner bool `json:"keep_previous_owner" db:"keep_previous_owner"`
+}
+
+type CreateTransferResponse struct {
+	Id        string    `json:"id" db:"id"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
+
+type CancelTransferRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	Id     string `validate:"uuid" db:"id"`
+	UserId string `validate:"uuid" db:"user_id"`
+}
+
+type MyTransfersRequest struct {
+	UserId string `prop:"user_id" validate:"uuid"`
+}
+
+type TransferItem struct {
+	Id         string `json:"id" db:"id"`
+	ShopId     string `json:"shop_id" db:"shop_id"`
+	ShopName   string `json:"shop_name" db:"shop_name"`
+	FromUserId string `json:"from_user_id" db:"from_user_id"`
+	// KeepPreviousOwner is what the owner asked for, the recipient may still decide otherwise.
+	KeepPreviousOwner bool      `json:"keep_previous_owner" db:"keep_previous_owner"`
+	ExpiresAt         time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+type MyTransfersResponse struct {
+	Items []TransferItem `json:"items"`
+}
+
+type RespondTransferRequest struct {
+	Id     string `validate:"uuid" db:"id"`
+	UserId string `validate:"uuid" db:"user_id"`
+	Accept bool
+
+	// KeepPreviousOwner overrides the choice made when the transfer was opened, accept only.
+	KeepPreviousOwner *bool `json:"keep_previous_owner"`
+}
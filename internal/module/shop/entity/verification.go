@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"codebase-app/pkg/types"
+	"time"
+)
+
+const (
+	VerificationPending   = "pending"
+	VerificationVerified  = "verified"
+	VerificationRejected  = "rejected"
+	VerificationSuspended = "suspended"
+)
+
+type RequestVerificationRequest struct {
+	ShopId string `validate:"uuid" db:"shop_id"`
+	UserId string `validate:"uuid" db:"actor_id"`
+}
+
+type UpdateVerificationRequest struct {
+	ShopId  string `validate:"uuid" db:"shop_id"`
+	ActorId string `validate:"uuid" db:"actor_id"`
+
+	Status string  `json:"status" validate:"required,oneof=pending verified rejected suspended" db:"status"`
+	Reason *string `json:"reason" validate:"omitempty,max=500" db:"reason"`
+}
+
+type VerificationResponse struct {
+	Id        string    `json:"id" db:"id"`
+	Status    string    `json:"status" db:"verification_status"`
+	Reason    *string   `json:"reason" db:"verification_reason"`
+	UpdatedAt time.Time `json:"updated_at" db:"verification_updated_at"`
+}
+
+type VerificationHistoryRequest struct {
+	ShopId   string `validate:"uuid" db:"shop_id"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *VerificationHistoryRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type VerificationHistoryItem struct {
+	Id        string    `json:"id" db:"id"`
+	Status    string    `json:"status" db:"status"`
+	Reason    *string   `json:"reason" db:"reason"`
+	ActorId   string    `json:"actor_id" db:"actor_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type VerificationHistoryResponse struct {
+	Items []VerificationHistoryItem `json:"items"`
+	Meta  types.Meta                `json:"meta"`
+}
+
+type AdminShopsRequest struct {
+	Status   string `query:"status" validate:"omitempty,oneof=pending verified rejected suspended"`
+	Page     int    `query:"page" validate:"required"`
+	Paginate int    `query:"paginate" validate:"required"`
+}
+
+func (r *AdminShopsRequest) SetDefault() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+
+	if r.Paginate < 1 {
+		r.Paginate = 10
+	}
+}
+
+type AdminShopItem struct {
+	Id                    string     `json:"id" db:"id"`
+	UserId                string     `json:"user_id" db:"user_id"`
+	Name                  string     `json:"name" db:"name"`
+	VerificationStatus    *string    `json:"verification_status" db:"verification_status"`
+	VerificationReason    *string    `json:"verification_reason" db:"verification_reason"`
+	VerificationUpdatedAt *time.Time `json:"verification_updated_at" db:"verification_updated_at"`
+	CreatedAt             time.Time  `json:"created_at" db:"created_at"`
+}
+
+type AdminShopsResponse struct {
+	Items []AdminShopItem `json:"items"`
+	Meta  types.Meta      `json:"meta"`
+}
@@ -2,20 +2,27 @@ package handler
 
 import (
 	"codebase-app/internal/adapter"
+	"codebase-app/internal/infrastructure"
+	"codebase-app/internal/infrastructure/config"
+	imageupload "codebase-app/internal/integration/imageupload"
 	"codebase-app/internal/middleware"
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
 	"codebase-app/internal/module/shop/repository"
 	"codebase-app/internal/module/shop/service"
 	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/fieldset"
 	"codebase-app/pkg/response"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 )
 
 type shopHandler struct {
-	service ports.ShopService
+	service  ports.ShopService
+	uploader imageupload.ImageUploadContract
 }
 
 func NewShopHandler() *shopHandler {
@@ -25,16 +32,71 @@ func NewShopHandler() *shopHandler {
 		service = service.NewShopService(repo)
 	)
 	handler.service = service
+	handler.uploader = imageupload.NewImageUploadIntegration()
+
+	var (
+		vacationInterval = time.Duration(config.Envs.Shop.VacationCheckInterval) * time.Second
+		stopVacations    = infrastructure.RunEvery("shop-vacation-end", vacationInterval, service.EndExpiredVacations)
+		statsInterval    = time.Duration(config.Envs.Shop.StatsRefreshInterval) * time.Second
+		stopStats        = infrastructure.RunEvery("shop-stats-refresh", statsInterval, service.RefreshShopStats)
+	)
+
+	adapter.Adapters.RestServer.Hooks().OnShutdown(func() error {
+		stopVacations()
+		stopStats()
+		return nil
+	})
 
 	return handler
 }
 
 func (h *shopHandler) Register(router fiber.Router) {
+	var (
+		admin = []fiber.Handler{middleware.AuthBearer, middleware.AuthRole([]string{"admin"})}
+	)
+
 	router.Get("/shops", middleware.UserIdHeader, h.GetShops)
 	router.Post("/shops", middleware.UserIdHeader, h.CreateShop)
-	router.Get("/shops/:id", h.GetShop)
+	router.Get("/shops/search", h.SearchShops)
+	router.Get("/shops/nearby", h.GetNearbyShops)
+	router.Get("/shops/:id", middleware.OptionalUserIdHeader, h.GetShop)
 	router.Delete("/shops/:id", middleware.UserIdHeader, h.DeleteShop)
 	router.Patch("/shops/:id", middleware.UserIdHeader, h.UpdateShop)
+	router.Post("/shops/:id/logo", middleware.UserIdHeader, h.UploadShopLogo)
+	router.Post("/shops/:id/banner", middleware.UserIdHeader, h.UploadShopBanner)
+	router.Patch("/shops/:id/branding", middleware.UserIdHeader, h.UpdateShopBranding)
+	router.Patch("/shops/:id/location", middleware.UserIdHeader, h.UpdateShopLocation)
+	router.Get("/shops/:id/stats", middleware.UserIdHeader, h.GetShopStats)
+	router.Post("/shops/:id/follow", middleware.UserIdHeader, h.FollowShop)
+	router.Delete("/shops/:id/follow", middleware.UserIdHeader, h.UnfollowShop)
+	router.Put("/shops/:id/operating-hours", middleware.UserIdHeader, h.UpdateOperatingHours)
+	router.Put("/shops/:id/vacation", middleware.UserIdHeader, h.UpdateShopVacation)
+	router.Delete("/shops/:id/vacation", middleware.UserIdHeader, h.EndShopVacation)
+	router.Get("/shops/:id/members", middleware.UserIdHeader, h.GetShopMembers)
+	router.Patch("/shops/:id/members/:user_id", middleware.UserIdHeader, h.UpdateShopMember)
+	router.Delete("/shops/:id/members/:user_id", middleware.UserIdHeader, h.DeleteShopMember)
+	router.Get("/shops/:id/invitations", middleware.UserIdHeader, h.GetShopInvitations)
+	router.Post("/shops/:id/invitations", middleware.UserIdHeader, h.CreateInvitation)
+	router.Delete("/shops/:id/invitations/:invitation_id", middleware.UserIdHeader, h.CancelInvitation)
+	router.Get("/invitations", middleware.UserIdHeader, h.GetMyInvitations)
+	router.Post("/invitations/:id/accept", middleware.UserIdHeader, h.AcceptInvitation)
+	router.Post("/invitations/:id/decline", middleware.UserIdHeader, h.DeclineInvitation)
+	router.Post("/shops/:id/transfers", middleware.UserIdHeader, h.CreateTransfer)
+	router.Delete("/shops/:id/transfers/:transfer_id", middleware.UserIdHeader, h.CancelTransfer)
+	router.Get("/transfers", middleware.UserIdHeader, h.GetMyTransfers)
+	router.Post("/transfers/:id/accept", middleware.UserIdHeader, h.AcceptTransfer)
+	router.Post("/transfers/:id/decline", middleware.UserIdHeader, h.DeclineTransfer)
+	router.Post("/shops/:id/verification", middleware.UserIdHeader, h.RequestVerification)
+	router.Get("/admin/shops", append(admin, h.GetAdminShops)...)
+	router.Patch("/admin/shops/:id/verification", append(admin, h.UpdateVerification)...)
+	router.Get("/admin/shops/:id/verification-history", append(admin, h.GetVerificationHistory)...)
+	router.Get("/admin/shops/:id/terms/acceptances", append(admin, h.GetTermsAcceptances)...)
+	router.Get("/shops/:id/terms/versions", h.GetTermsVersions)
+	router.Get("/shops/:id/terms/versions/:version", h.GetTermsVersion)
+	router.Post("/shops/:id/terms/acceptances", middleware.UserIdHeader, h.RecordTermsAcceptance)
+	router.Get("/shops/:id/translations", middleware.UserIdHeader, h.GetShopTranslations)
+	router.Put("/shops/:id/translations/:locale", middleware.UserIdHeader, h.UpsertShopTranslation)
+	router.Delete("/shops/:id/translations/:locale", middleware.UserIdHeader, h.DeleteShopTranslation)
 }
 
 func (h *shopHandler) CreateShop(c *fiber.Ctx) error {
@@ -73,9 +135,12 @@ func (h *shopHandler) GetShop(c *fiber.Ctx) error {
 		req = new(entity.GetShopRequest)
 		ctx = c.Context()
 		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
 	)
 
 	req.Id = c.Params("id")
+	req.Locale = middleware.GetLocale(c)
+	req.UserId = l.UserId
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::GetShop - Validate request body")
@@ -94,14 +159,19 @@ func (h *shopHandler) GetShop(c *fiber.Ctx) error {
 
 func (h *shopHandler) DeleteShop(c *fiber.Ctx) error {
 	var (
-		req        = new(entity.DeleteShopRequest)
-		reqGetShop = new(entity.GetShopRequest)
-		ctx        = c.Context()
-		v          = adapter.Adapters.Validator
-		l          = middleware.GetLocals(c)
+		req = new(entity.DeleteShopRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
 	)
-	OwnerId := l.UserId
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::DeleteShop - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
 	req.Id = c.Params("id")
+	req.UserId = l.UserId
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteShop - Validate request body")
@@ -109,18 +179,24 @@ func (h *shopHandler) DeleteShop(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
-	reqGetShop.Id = req.Id
-	respExistingShop, err := h.service.VerifyShopExists(ctx, reqGetShop)
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.Id,
+		UserId:     l.UserId,
+		Permission: entity.PermShopDelete,
+	})
 	if err != nil {
 		code, errs := errmsg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
-	if respExistingShop.UserId != OwnerId {
-		log.Warn().Err(err).Msg("handler::UpdateShop - Unauthorized")
-		return c.Status(403).JSON(response.Error(
-			"Terlarang: anda tidak diizinkan untuk mengakses resource ini",
-		))
+	if req.DryRun {
+		resp, err := h.service.PreviewDeleteShop(ctx, req)
+		if err != nil {
+			code, errs := errmsg.Errors[error](err)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+
+		return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
 	}
 
 	err = h.service.DeleteShop(ctx, req)
@@ -134,11 +210,10 @@ func (h *shopHandler) DeleteShop(c *fiber.Ctx) error {
 
 func (h *shopHandler) UpdateShop(c *fiber.Ctx) error {
 	var (
-		req        = new(entity.UpdateShopRequest)
-		reqGetShop = new(entity.GetShopRequest)
-		ctx        = c.Context()
-		v          = adapter.Adapters.Validator
-		l          = middleware.GetLocals(c)
+		req = new(entity.UpdateShopRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
 	)
 
 	if err := c.BodyParser(req); err != nil {
@@ -146,8 +221,8 @@ func (h *shopHandler) UpdateShop(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
 	}
 
-	OwnerId := l.UserId
 	req.Id = c.Params("id")
+	req.UserId = l.UserId
 
 	if err := v.Validate(req); err != nil {
 		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateShop - Validate request body")
@@ -155,20 +230,16 @@ func (h *shopHandler) UpdateShop(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
-	reqGetShop.Id = req.Id
-	respExistingShop, err := h.service.VerifyShopExists(ctx, reqGetShop)
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.Id,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
 	if err != nil {
 		code, errs := errmsg.Errors[error](err)
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
-	if respExistingShop.UserId != OwnerId {
-		log.Warn().Err(err).Msg("handler::UpdateShop - Unauthorized")
-		return c.Status(403).JSON(response.Error(
-			"Terlarang: anda tidak diizinkan untuk mengakses resource ini",
-		))
-	}
-
 	resp, err := h.service.UpdateShop(ctx, req)
 	if err != nil {
 		code, errs := errmsg.Errors[error](err)
@@ -192,6 +263,7 @@ func (h *shopHandler) GetShops(c *fiber.Ctx) error {
 	}
 
 	req.UserId = l.UserId
+	req.Locale = l.Locale
 	req.SetDefault()
 
 	if err := v.Validate(req); err != nil {
@@ -206,6 +278,450 @@ func (h *shopHandler) GetShops(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	if fields := req.FieldList(); len(fields) > 0 {
+		items, err := fieldset.Project(resp.Items, fields...)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("handler::GetShops - Project fields")
+			return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+		}
+
+		return c.Status(fiber.StatusOK).JSON(response.Success(fiber.Map{"items": items, "meta": resp.Meta}, ""))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+
+}
+
+func (h *shopHandler) GetShopTranslations(c *fiber.Ctx) error {
+	var (
+		req = new(entity.ShopTranslationsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.ShopId = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetShopTranslations - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetShopTranslations(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) UpsertShopTranslation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpsertShopTranslationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpsertShopTranslation - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.Locale = strings.ToLower(c.Params("locale"))
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpsertShopTranslation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.service.UpsertShopTranslation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *shopHandler) DeleteShopTranslation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.DeleteShopTranslationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.ShopId = c.Params("id")
+	req.Locale = strings.ToLower(c.Params("locale"))
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::DeleteShopTranslation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.service.DeleteShopTranslation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+var (
+	shopLogoRules = imageupload.Rules{
+		MaxSize:  2 << 20,
+		MinWidth: 128, MinHeight: 128,
+		MaxWidth: 2048, MaxHeight: 2048,
+	}
+	shopBannerRules = imageupload.Rules{
+		MaxSize:  4 << 20,
+		MinWidth: 1200, MinHeight: 300,
+		MaxWidth: 4096, MaxHeight: 2048,
+	}
+)
+
+func (h *shopHandler) UploadShopLogo(c *fiber.Ctx) error {
+	return h.uploadShopAsset(c, "logo", shopLogoRules)
+}
+
+func (h *shopHandler) UploadShopBanner(c *fiber.Ctx) error {
+	return h.uploadShopAsset(c, "banner", shopBannerRules)
+}
+
+func (h *shopHandler) uploadShopAsset(c *fiber.Ctx, asset string, rules imageupload.Rules) error {
+	var (
+		req = new(entity.UploadShopAssetRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.Id = c.Params("id")
+	req.File, _ = c.FormFile("file")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::uploadShopAsset - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.Id,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	url, err := h.uploader.Upload(ctx, req.File, "shops/"+req.Id, rules)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateShopAsset(ctx, &entity.UpdateShopAssetRequest{
+		Id:    req.Id,
+		Asset: asset,
+		Url:   url,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) UpdateShopBranding(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateShopBrandingRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateShopBranding - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateShopBranding - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.Id,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateShopBranding(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) UpdateShopLocation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateShopLocationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateShopLocation - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateShopLocation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.Id,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateShopLocation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) SearchShops(c *fiber.Ctx) error {
+	var (
+		req = new(entity.SearchShopsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::SearchShops - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Locale = middleware.GetLocale(c)
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::SearchShops - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.SearchShops(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) GetNearbyShops(c *fiber.Ctx) error {
+	var (
+		req = new(entity.NearbyShopsRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+	)
+
+	if err := c.QueryParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetNearbyShops - Parse request query")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Locale = middleware.GetLocale(c)
+	req.SetDefault()
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetNearbyShops - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetNearbyShops(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
 	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) UpdateOperatingHours(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateOperatingHoursRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateOperatingHours - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateOperatingHours - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.Id,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateOperatingHours(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) UpdateShopVacation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.UpdateShopVacationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateShopVacation - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.Id = c.Params("id")
 
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::UpdateShopVacation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.Id,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.UpdateShopVacation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) EndShopVacation(c *fiber.Ctx) error {
+	var (
+		req = new(entity.EndShopVacationRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.Id = c.Params("id")
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::EndShopVacation - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.Id,
+		UserId:     l.UserId,
+		Permission: entity.PermShopManage,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.service.EndShopVacation(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
 }
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"codebase-app/internal/adapter"
+	"codebase-app/internal/middleware"
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *shopHandler) CreateTransfer(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CreateTransferRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	if err := c.BodyParser(req); err != nil {
+		log.Warn().Err(err).Msg("handler::CreateTransfer - Parse request body")
+		return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+	}
+
+	req.ShopId = c.Params("id")
+	req.FromUserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CreateTransfer - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: entity.PermShopTransfer,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.CreateTransfer(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) CancelTransfer(c *fiber.Ctx) error {
+	var (
+		req = new(entity.CancelTransferRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.ShopId = c.Params("id")
+	req.Id = c.Params("transfer_id")
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::CancelTransfer - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.CheckPermission(ctx, &entity.CheckPermissionRequest{
+		ShopId:     req.ShopId,
+		UserId:     l.UserId,
+		Permission: entity.PermShopTransfer,
+	})
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err = h.service.CancelTransfer(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
+
+func (h *shopHandler) GetMyTransfers(c *fiber.Ctx) error {
+	var (
+		req = new(entity.MyTransfersRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	req.UserId = l.UserId
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::GetMyTransfers - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	resp, err := h.service.GetMyTransfers(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(resp, ""))
+}
+
+func (h *shopHandler) AcceptTransfer(c *fiber.Ctx) error {
+	return h.respondTransfer(c, true)
+}
+
+func (h *shopHandler) DeclineTransfer(c *fiber.Ctx) error {
+	return h.respondTransfer(c, false)
+}
+
+func (h *shopHandler) respondTransfer(c *fiber.Ctx, accept bool) error {
+	var (
+		req = new(entity.RespondTransferRequest)
+		ctx = c.Context()
+		v   = adapter.Adapters.Validator
+		l   = middleware.GetLocals(c)
+	)
+
+	// the body is optional, it only carries the choice to keep the previous owner
+	if accept && len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			log.Warn().Err(err).Msg("handler::respondTransfer - Parse request body")
+			return c.Status(fiber.StatusBadRequest).JSON(response.Error(err))
+		}
+	}
+
+	req.Id = c.Params("id")
+	req.UserId = l.UserId
+	req.Accept = accept
+
+	if err := v.Validate(req); err != nil {
+		log.Warn().Err(err).Any("payload", req).Msg("handler::respondTransfer - Validate request body")
+		code, errs := errmsg.Errors(err, req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	err := h.service.RespondTransfer(ctx, req)
+	if err != nil {
+		code, errs := errmsg.Errors[error](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.Success(nil, ""))
+}
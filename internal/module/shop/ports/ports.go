@@ -10,8 +10,47 @@ type ShopRepository interface {
 	GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error)
 	VerifyShopExists(ctx context.Context, req *entity.GetShopRequest) (*entity.GetExistingShopResponse, error)
 	DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error
+	PreviewDeleteShop(ctx context.Context, req *entity.DeleteShopRequest) (*entity.DeleteShopPreviewResponse, error)
 	UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error)
 	GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error)
+	GetShopTranslations(ctx context.Context, req *entity.ShopTranslationsRequest) (*entity.ShopTranslationsResponse, error)
+	UpsertShopTranslation(ctx context.Context, req *entity.UpsertShopTranslationRequest) error
+	DeleteShopTranslation(ctx context.Context, req *entity.DeleteShopTranslationRequest) error
+	UpdateShopAsset(ctx context.Context, req *entity.UpdateShopAssetRequest) (*entity.UpdateShopAssetResponse, error)
+	UpdateShopBranding(ctx context.Context, req *entity.UpdateShopBrandingRequest) (*entity.UpdateShopBrandingResponse, error)
+	UpdateShopLocation(ctx context.Context, req *entity.UpdateShopLocationRequest) (*entity.UpdateShopLocationResponse, error)
+	SearchShops(ctx context.Context, req *entity.SearchShopsRequest) (*entity.SearchShopsResponse, error)
+	GetNearbyShops(ctx context.Context, req *entity.NearbyShopsRequest) (*entity.NearbyShopsResponse, error)
+	GetOperatingHours(ctx context.Context, shopId string) ([]entity.OperatingHourItem, error)
+	UpdateOperatingHours(ctx context.Context, req *entity.UpdateOperatingHoursRequest) (*entity.UpdateOperatingHoursResponse, error)
+	UpdateShopVacation(ctx context.Context, req *entity.UpdateShopVacationRequest) (*entity.UpdateShopVacationResponse, error)
+	EndShopVacation(ctx context.Context, req *entity.EndShopVacationRequest) error
+	EndExpiredVacations(ctx context.Context) (int64, error)
+	GetMemberRole(ctx context.Context, shopId, userId string) (*entity.GetMemberRoleResponse, error)
+	GetShopMembers(ctx context.Context, req *entity.ShopMembersRequest) (*entity.ShopMembersResponse, error)
+	UpdateShopMember(ctx context.Context, req *entity.UpdateShopMemberRequest) error
+	DeleteShopMember(ctx context.Context, req *entity.DeleteShopMemberRequest) error
+	CreateInvitation(ctx context.Context, req *entity.CreateInvitationRequest) (*entity.CreateInvitationResponse, error)
+	GetShopInvitations(ctx context.Context, req *entity.ShopInvitationsRequest) (*entity.InvitationsResponse, error)
+	GetMyInvitations(ctx context.Context, req *entity.MyInvitationsRequest) (*entity.InvitationsResponse, error)
+	CancelInvitation(ctx context.Context, req *entity.CancelInvitationRequest) error
+	RespondInvitation(ctx context.Context, req *entity.RespondInvitationRequest) error
+	CreateTransfer(ctx context.Context, req *entity.CreateTransferRequest) (*entity.CreateTransferResponse, error)
+	CancelTransfer(ctx context.Context, req *entity.CancelTransferRequest) error
+	GetMyTransfers(ctx context.Context, req *entity.MyTransfersRequest) (*entity.MyTransfersResponse, error)
+	RespondTransfer(ctx context.Context, req *entity.RespondTransferRequest) error
+	RequestVerification(ctx context.Context, req *entity.RequestVerificationRequest) (*entity.VerificationResponse, error)
+	UpdateVerification(ctx context.Context, req *entity.UpdateVerificationRequest) (*entity.VerificationResponse, error)
+	GetVerificationHistory(ctx context.Context, req *entity.VerificationHistoryRequest) (*entity.VerificationHistoryResponse, error)
+	GetAdminShops(ctx context.Context, req *entity.AdminShopsRequest) (*entity.AdminShopsResponse, error)
+	GetShopStats(ctx context.Context, req *entity.ShopStatsRequest) (*entity.ShopStatsResponse, error)
+	RefreshShopStats(ctx context.Context) error
+	FollowShop(ctx context.Context, req *entity.FollowShopRequest) error
+	UnfollowShop(ctx context.Context, req *entity.FollowShopRequest) error
+	GetTermsVersions(ctx context.Context, req *entity.TermsVersionsRequest) (*entity.TermsVersionsResponse, error)
+	GetTermsVersion(ctx context.Context, req *entity.GetTermsVersionRequest) (*entity.TermsVersionItem, error)
+	RecordTermsAcceptance(ctx context.Context, req *entity.RecordTermsAcceptanceRequest) (*entity.RecordTermsAcceptanceResponse, error)
+	GetTermsAcceptances(ctx context.Context, req *entity.TermsAcceptancesRequest) (*entity.TermsAcceptancesResponse, error)
 }
 
 type ShopService interface {
@@ -19,6 +58,44 @@ type ShopService interface {
 	GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error)
 	VerifyShopExists(ctx context.Context, req *entity.GetShopRequest) (*entity.GetExistingShopResponse, error)
 	DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error
+	PreviewDeleteShop(ctx context.Context, req *entity.DeleteShopRequest) (*entity.DeleteShopPreviewResponse, error)
 	UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error)
 	GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error)
+	GetShopTranslations(ctx context.Context, req *entity.ShopTranslationsRequest) (*entity.ShopTranslationsResponse, error)
+	UpsertShopTranslation(ctx context.Context, req *entity.UpsertShopTranslationRequest) error
+	DeleteShopTranslation(ctx context.Context, req *entity.DeleteShopTranslationRequest) error
+	UpdateShopAsset(ctx context.Context, req *entity.UpdateShopAssetRequest) (*entity.UpdateShopAssetResponse, error)
+	UpdateShopBranding(ctx context.Context, req *entity.UpdateShopBrandingRequest) (*entity.UpdateShopBrandingResponse, error)
+	UpdateShopLocation(ctx context.Context, req *entity.UpdateShopLocationRequest) (*entity.UpdateShopLocationResponse, error)
+	SearchShops(ctx context.Context, req *entity.SearchShopsRequest) (*entity.SearchShopsResponse, error)
+	GetNearbyShops(ctx context.Context, req *entity.NearbyShopsRequest) (*entity.NearbyShopsResponse, error)
+	UpdateOperatingHours(ctx context.Context, req *entity.UpdateOperatingHoursRequest) (*entity.UpdateOperatingHoursResponse, error)
+	UpdateShopVacation(ctx context.Context, req *entity.UpdateShopVacationRequest) (*entity.UpdateShopVacationResponse, error)
+	EndShopVacation(ctx context.Context, req *entity.EndShopVacationRequest) error
+	EndExpiredVacations(ctx context.Context) error
+	CheckPermission(ctx context.Context, req *entity.CheckPermissionRequest) error
+	GetShopMembers(ctx context.Context, req *entity.ShopMembersRequest) (*entity.ShopMembersResponse, error)
+	UpdateShopMember(ctx context.Context, req *entity.UpdateShopMemberRequest) error
+	DeleteShopMember(ctx context.Context, req *entity.DeleteShopMemberRequest) error
+	CreateInvitation(ctx context.Context, req *entity.CreateInvitationRequest) (*entity.CreateInvitationResponse, error)
+	GetShopInvitations(ctx context.Context, req *entity.ShopInvitationsRequest) (*entity.InvitationsResponse, error)
+	GetMyInvitations(ctx context.Context, req *entity.MyInvitationsRequest) (*entity.InvitationsResponse, error)
+	CancelInvitation(ctx context.Context, req *entity.CancelInvitationRequest) error
+	RespondInvitation(ctx context.Context, req *entity.RespondInvitationRequest) error
+	CreateTransfer(ctx context.Context, req *entity.CreateTransferRequest) (*entity.CreateTransferResponse, error)
+	CancelTransfer(ctx context.Context, req *entity.CancelTransferRequest) error
+	GetMyTransfers(ctx context.Context, req *entity.MyTransfersRequest) (*entity.MyTransfersResponse, error)
+	RespondTransfer(ctx context.Context, req *entity.RespondTransferRequest) error
+	RequestVerification(ctx context.Context, req *entity.RequestVerificationRequest) (*entity.VerificationResponse, error)
+	UpdateVerification(ctx context.Context, req *entity.UpdateVerificationRequest) (*entity.VerificationResponse, error)
+	GetVerificationHistory(ctx context.Context, req *entity.VerificationHistoryRequest) (*entity.VerificationHistoryResponse, error)
+	GetAdminShops(ctx context.Context, req *entity.AdminShopsRequest) (*entity.AdminShopsResponse, error)
+	GetShopStats(ctx context.Context, req *entity.ShopStatsRequest) (*entity.ShopStatsResponse, error)
+	RefreshShopStats(ctx context.Context) error
+	FollowShop(ctx context.Context, req *entity.FollowShopRequest) error
+	UnfollowShop(ctx context.Context, req *entity.FollowShopRequest) error
+	GetTermsVersions(ctx context.Context, req *entity.TermsVersionsRequest) (*entity.TermsVersionsResponse, error)
+	GetTermsVersion(ctx context.Context, req *entity.GetTermsVersionRequest) (*entity.TermsVersionItem, error)
+	RecordTermsAcceptance(ctx context.Context, req *entity.RecordTermsAcceptanceRequest) (*entity.RecordTermsAcceptanceResponse, error)
+	GetTermsAcceptances(ctx context.Context, req *entity.TermsAcceptancesRequest) (*entity.TermsAcceptancesResponse, error)
 }
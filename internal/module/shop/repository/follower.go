@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FollowShop is idempotent, following a shop twice keeps the first follow date.
+func (r *shopRepository) FollowShop(ctx context.Context, req *entity.FollowShopRequest) error {
+	query := `
+		INSERT INTO shop_followers (shop_id, user_id)
+		VALUES (?, ?)
+		ON CONFLICT (shop_id, user_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.ShopId, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::FollowShop - Failed to follow shop")
+		return err
+	}
+
+	return nil
+}
+
+func (r *shopRepository) UnfollowShop(ctx context.Context, req *entity.FollowShopRequest) error {
+	query := `
+		DELETE FROM shop_followers
+		WHERE
+			shop_id = ?
+			AND user_id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.ShopId, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UnfollowShop - Failed to unfollow shop")
+		return err
+	}
+
+	return nil
+}
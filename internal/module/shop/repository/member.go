@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/types"
+	"context"
+	"database/sql"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetMemberRole returns the role of the user in the shop, the role is nil when the user is not a member.
+// A malformed user id, ex: a forged X-USER-ID header, is no member of any shop.
+func (r *shopRepository) GetMemberRole(ctx context.Context, shopId, userId string) (*entity.GetMemberRoleResponse, error) {
+	var (
+		resp   = new(entity.GetMemberRoleResponse)
+		member any
+	)
+
+	if types.IsUUID(userId) {
+		member = userId
+	}
+
+	query := `
+		SELECT
+			s.id AS shop_id,
+			m.role
+		FROM shops s
+		LEFT JOIN
+			shop_members m ON m.shop_id = s.id AND m.user_id = ?::uuid
+		WHERE
+			s.deleted_at IS NULL
+			AND s.id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), member, shopId).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Warn().Err(err).Str("shop_id", shopId).Msg("repository::GetMemberRole - Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Str("shop_id", shopId).Str("user_id", userId).Msg("repository::GetMemberRole - Failed to get member role")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) GetShopMembers(ctx context.Context, req *entity.ShopMembersRequest) (*entity.ShopMembersResponse, error) {
+	var resp = new(entity.ShopMembersResponse)
+	resp.Items = make([]entity.ShopMemberItem, 0)
+
+	query := `
+		SELECT user_id, role, created_at
+		FROM shop_members
+		WHERE shop_id = ?
+		ORDER BY (role = 'owner') DESC, created_at ASC
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetShopMembers - Failed to get shop members")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) UpdateShopMember(ctx context.Context, req *entity.UpdateShopMemberRequest) error {
+	query := `
+		UPDATE shop_members
+		SET
+			role = ?,
+			updated_at = NOW()
+		WHERE
+			shop_id = ?
+			AND user_id = ?
+			AND role <> 'owner'
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Role, req.ShopId, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShopMember - Failed to update shop member")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Anggota toko tidak ditemukan"))
+	}
+
+	return nil
+}
+
+func (r *shopRepository) DeleteShopMember(ctx context.Context, req *entity.DeleteShopMemberRequest) error {
+	query := `
+		DELETE FROM shop_members
+		WHERE
+			shop_id = ?
+			AND user_id = ?
+			AND role <> 'owner'
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.ShopId, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShopMember - Failed to delete shop member")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Anggota toko tidak ditemukan"))
+	}
+
+	return nil
+}
+
+// CreateInvitation invites a user who is not a member yet. Inviting a user with a pending
+// invitation refreshes it with the new role and expiry instead of adding another one.
+func (r *shopRepository) CreateInvitation(ctx context.Context, req *entity.CreateInvitationRequest) (*entity.CreateInvitationResponse, error) {
+	var resp = new(entity.CreateInvitationResponse)
+
+	query := `
+		INSERT INTO shop_invitations (shop_id, user_id, role, invited_by, expires_at)
+		SELECT ?::uuid, ?::uuid, ?, ?::uuid, NOW() + INTERVAL '7 days'
+		WHERE NOT EXISTS (
+			SELECT 1 FROM shop_members WHERE shop_id = ? AND user_id = ?
+		)
+		ON CONFLICT (shop_id, user_id) WHERE status = 'pending' DO UPDATE SET
+			role = EXCLUDED.role,
+			invited_by = EXCLUDED.invited_by,
+			expires_at = EXCLUDED.expires_at,
+			updated_at = NOW()
+		RETURNING id, expires_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.ShopId,
+		req.UserId,
+		req.Role,
+		req.InvitedBy,
+		req.ShopId,
+		req.UserId,
+	).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithErrors("user_id", "pengguna sudah menjadi anggota toko."))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateInvitation - Failed to create invitation")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) GetShopInvitations(ctx context.Context, req *entity.ShopInvitationsRequest) (*entity.InvitationsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.InvitationItem
+	}
+
+	var (
+		resp = new(entity.InvitationsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.InvitationItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(i.id) OVER() as total_data,
+			i.id,
+			i.shop_id,
+			s.name AS shop_name,
+			i.user_id,
+			i.role,
+			i.status,
+			i.invited_by,
+			i.expires_at,
+			i.created_at
+		FROM shop_invitations i
+		JOIN
+			shops s ON s.id = i.shop_id
+		WHERE
+			i.shop_id = ?
+			AND (? = '' OR i.status = ?)
+		ORDER BY i.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ShopId,
+		req.Status,
+		req.Status,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetShopInvitations - Failed to get invitations")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.InvitationItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *shopRepository) GetMyInvitations(ctx context.Context, req *entity.MyInvitationsRequest) (*entity.InvitationsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.InvitationItem
+	}
+
+	var (
+		resp = new(entity.InvitationsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.InvitationItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(i.id) OVER() as total_data,
+			i.id,
+			i.shop_id,
+			s.name AS shop_name,
+			i.user_id,
+			i.role,
+			i.status,
+			i.invited_by,
+			i.expires_at,
+			i.created_at
+		FROM shop_invitations i
+		JOIN
+			shops s ON s.id = i.shop_id AND s.deleted_at IS NULL
+		WHERE
+			i.user_id = ?
+			AND i.status = 'pending'
+			AND i.expires_at > NOW()
+		ORDER BY i.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.UserId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetMyInvitations - Failed to get invitations")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.InvitationItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *shopRepository) CancelInvitation(ctx context.Context, req *entity.CancelInvitationRequest) error {
+	query := `
+		UPDATE shop_invitations
+		SET
+			status = 'cancelled',
+			updated_at = NOW()
+		WHERE
+			id = ?
+			AND shop_id = ?
+			AND status = 'pending'
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id, req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CancelInvitation - Failed to cancel invitation")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Undangan tidak ditemukan"))
+	}
+
+	return nil
+}
+
+// RespondInvitation accepts or declines a pending invitation of the user.
+// Accepting adds the membership in the same transaction.
+func (r *shopRepository) RespondInvitation(ctx context.Context, req *entity.RespondInvitationRequest) error {
+	type dao struct {
+		ShopId string `db:"shop_id"`
+		Role   string `db:"role"`
+	}
+
+	var (
+		invitation = new(dao)
+		status     = "declined"
+	)
+
+	if req.Accept {
+		status = "accepted"
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RespondInvitation - Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE shop_invitations i
+		SET
+			status = ?,
+			responded_at = NOW(),
+			updated_at = NOW()
+		FROM shops s
+		WHERE
+			s.id = i.shop_id
+			AND s.deleted_at IS NULL
+			AND i.id = ?
+			AND i.user_id = ?
+			AND i.status = 'pending'
+			AND i.expires_at > NOW()
+		RETURNING i.shop_id, i.role
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query), status, req.Id, req.UserId).StructScan(invitation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errmsg.NewCustomErrors(404, errmsg.WithMessage("Undangan tidak ditemukan atau sudah kedaluwarsa"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::RespondInvitation - Failed to update invitation")
+		return err
+	}
+
+	if req.Accept {
+		query := `
+			INSERT INTO shop_members (shop_id, user_id, role)
+			VALUES (?, ?, ?)
+			ON CONFLICT (shop_id, user_id) DO NOTHING
+		`
+
+		_, err = tx.ExecContext(ctx, r.db.Rebind(query), invitation.ShopId, req.UserId, invitation.Role)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::RespondInvitation - Failed to add shop member")
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RespondInvitation - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
@@ -4,10 +4,14 @@ import (
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
 	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/fieldset"
+	"codebase-app/pkg/outbox"
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
@@ -25,13 +29,20 @@ func NewShopRepository(db *sqlx.DB) *shopRepository {
 
 func (r *shopRepository) CreateShop(ctx context.Context, req *entity.CreateShopRequest) (*entity.CreateShopResponse, error) {
 	var resp = new(entity.CreateShopResponse)
-	// Your code here
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO shops (user_id, name, description, terms)
 		VALUES (?, ?, ?, ?) RETURNING id
 	`
 
-	err := r.db.QueryRowContext(ctx, r.db.Rebind(query),
+	err = tx.QueryRowContext(ctx, r.db.Rebind(query),
 		req.UserId,
 		req.Name,
 		req.Description,
@@ -41,6 +52,33 @@ func (r *shopRepository) CreateShop(ctx context.Context, req *entity.CreateShopR
 		return nil, err
 	}
 
+	query = `
+		INSERT INTO shop_members (shop_id, user_id, role)
+		VALUES (?, ?, 'owner')
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), resp.Id, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to add shop owner")
+		return nil, err
+	}
+
+	query = `
+		INSERT INTO shop_terms_versions (shop_id, version, terms, created_by)
+		VALUES (?, 1, ?, ?)
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), resp.Id, req.Terms, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to insert terms version")
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateShop - Failed to commit transaction")
+		return nil, err
+	}
+
 	return resp, nil
 }
 
@@ -48,14 +86,42 @@ func (r *shopRepository) GetShop(ctx context.Context, req *entity.GetShopRequest
 	var resp = new(entity.GetShopResponse)
 	// Your code here
 	query := `
-		SELECT name, description, terms
-		FROM shops
+		SELECT
+			COALESCE(st.name, s.name) AS name,
+			COALESCE(st.description, s.description) AS description,
+			s.terms,
+			s.terms_version,
+			st.terms AS translated_terms,
+			s.logo_url,
+			s.banner_url,
+			s.accent_color,
+			s.social_links,
+			s.address,
+			s.city,
+			s.province,
+			s.postal_code,
+			s.location,
+			s.timezone,
+			shop_is_open(s.id) AS is_open,
+			s.vacation_mode,
+			to_char(s.vacation_start, 'YYYY-MM-DD') AS vacation_start,
+			to_char(s.vacation_end, 'YYYY-MM-DD') AS vacation_end,
+			s.vacation_message,
+			s.verification_status,
+			s.verification_reason,
+			(SELECT COUNT(*) FROM shop_followers f WHERE f.shop_id = s.id) AS follower_count,
+			EXISTS (
+				SELECT 1 FROM shop_followers f WHERE f.shop_id = s.id AND f.user_id = NULLIF(?, '')::uuid
+			) AS following
+		FROM shops s
+		LEFT JOIN
+			shop_translations st ON st.shop_id = s.id AND st.locale = ?
 		WHERE
-			deleted_at IS NULL
-			AND id = ?
+			s.deleted_at IS NULL
+			AND s.id = ?
 	`
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(resp)
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.UserId, req.Locale, req.Id).StructScan(resp)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Error().Err(err).Any("payload", req).Msg("repository::GetShop - Shop not found")
@@ -94,39 +160,182 @@ func (r *shopRepository) VerifyShopExists(ctx context.Context, req *entity.GetSh
 	return resp, nil
 }
 
+// DeleteShop soft-deletes the shop together with its products and cancels the pending
+// invitations and transfers in one transaction. Members, followers and terms are kept
+// for the history, they are hidden along with the shop.
 func (r *shopRepository) DeleteShop(ctx context.Context, req *entity.DeleteShopRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE shops
-		SET deleted_at = NOW()
-		WHERE id = ?
+		SET
+			deleted_at = NOW(),
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
 	`
 
-	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id)
+	result, err := tx.ExecContext(ctx, r.db.Rebind(query), req.Id)
 	if err != nil {
 		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to delete shop")
 		return err
 	}
 
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+
+	// every deleted product gets its product.deleted event from the same statement
+	rows := `
+		UPDATE products
+		SET
+			deleted_at = NOW(),
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND shop_id = ?
+		RETURNING
+			id AS aggregate_id,
+			jsonb_build_object('product_id', id, 'shop_id', shop_id, 'reason', 'shop_deleted') AS payload
+	`
+
+	products, err := outbox.PublishRows(ctx, tx, "product", "product.deleted", rows, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to delete products")
+		return err
+	}
+
+	for _, query := range []string{
+		`UPDATE shop_invitations SET status = 'cancelled', updated_at = NOW() WHERE shop_id = ? AND status = 'pending'`,
+		`UPDATE shop_transfers SET status = 'cancelled', responded_at = NOW(), updated_at = NOW() WHERE shop_id = ? AND status = 'pending'`,
+	} {
+		if _, err = tx.ExecContext(ctx, r.db.Rebind(query), req.Id); err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to cancel pending requests")
+			return err
+		}
+	}
+
+	err = outbox.Publish(ctx, tx, outbox.Event{
+		AggregateType: "shop",
+		AggregateId:   req.Id,
+		EventType:     "shop.deleted",
+		Payload: map[string]any{
+			"shop_id":  req.Id,
+			"products": products,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = r.writeAuditLog(ctx, tx, req.Id, req.UserId, "shop.deleted", map[string]any{
+		"products": products,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShop - Failed to commit transaction")
+		return err
+	}
+
 	return nil
 }
 
+func (r *shopRepository) PreviewDeleteShop(ctx context.Context, req *entity.DeleteShopRequest) (*entity.DeleteShopPreviewResponse, error) {
+	var resp = new(entity.DeleteShopPreviewResponse)
+
+	query := `
+		SELECT
+			s.id AS shop_id,
+			(SELECT COUNT(*) FROM products p WHERE p.shop_id = s.id AND p.deleted_at IS NULL) AS products,
+			(SELECT COUNT(*) FROM shop_invitations i WHERE i.shop_id = s.id AND i.status = 'pending') AS pending_invitations,
+			(SELECT COUNT(*) FROM shop_transfers t WHERE t.shop_id = s.id AND t.status = 'pending') AS pending_transfers,
+			(SELECT COUNT(*) FROM shop_members m WHERE m.shop_id = s.id) AS members,
+			(SELECT COUNT(*) FROM shop_followers f WHERE f.shop_id = s.id) AS followers
+		FROM shops s
+		WHERE
+			s.deleted_at IS NULL
+			AND s.id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.Id).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::PreviewDeleteShop - Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::PreviewDeleteShop - Failed to count affected rows")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// UpdateShop keeps every terms revision, a changed terms bumps shops.terms_version and
+// is stored as a new version in the same transaction. A new low-stock threshold notifies
+// about the products it makes low, as a stock change would.
 func (r *shopRepository) UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error) {
-	var resp = new(entity.UpdateShopResponse)
+	var (
+		resp      = new(entity.UpdateShopResponse)
+		threshold int
+	)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
 
 	query := `
+		SELECT low_stock_threshold
+		FROM shops
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+		FOR UPDATE
+	`
+
+	err = tx.GetContext(ctx, &threshold, r.db.Rebind(query), req.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to lock shop")
+		return nil, err
+	}
+
+	query = `
 		UPDATE shops
-		SET name = ?, description = ?, terms = ?, updated_at = NOW()
+		SET
+			name = ?,
+			description = ?,
+			terms_version = CASE WHEN terms = ? THEN terms_version ELSE terms_version + 1 END,
+			terms = ?,
+			low_stock_threshold = COALESCE(?, low_stock_threshold),
+			updated_at = NOW()
 		WHERE
 			deleted_at IS NULL
 			AND id = ?
-		RETURNING id
+		RETURNING id, terms_version
 	`
 
-	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query),
 		req.Name,
 		req.Description,
 		req.Terms,
-		req.Id).Scan(&resp.Id)
+		req.Terms,
+		req.LowStockThreshold,
+		req.Id).StructScan(resp)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Shop not found")
@@ -137,9 +346,69 @@ func (r *shopRepository) UpdateShop(ctx context.Context, req *entity.UpdateShopR
 		}
 	}
 
+	// a no-op when the terms did not change, that version is already stored
+	query = `
+		INSERT INTO shop_terms_versions (shop_id, version, terms, created_by)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (shop_id, version) DO NOTHING
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), resp.Id, resp.TermsVersion, req.Terms, req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to insert terms version")
+		return nil, err
+	}
+
+	if req.LowStockThreshold != nil && *req.LowStockThreshold > threshold {
+		if err = r.notifyLowStock(ctx, tx, resp.Id, threshold, *req.LowStockThreshold); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShop - Failed to commit transaction")
+		return nil, err
+	}
+
 	return resp, nil
 }
 
+// notifyLowStock stores a notification and emits a product.low_stock domain event for every
+// product of the shop that a raised shop threshold makes low, that is the products without
+// their own threshold whose stock sits between the previous and the new one.
+func (r *shopRepository) notifyLowStock(ctx context.Context, tx *sqlx.Tx, shopId string, previous, threshold int) error {
+	rows := `
+		INSERT INTO stock_notifications (shop_id, product_id, stock, threshold, source)
+		SELECT shop_id, id, stock, ?, 'shop_threshold'
+		FROM products
+		WHERE
+			deleted_at IS NULL
+			AND shop_id = ?
+			AND low_stock_threshold IS NULL
+			AND stock > ?
+			AND stock <= ?
+		RETURNING
+			product_id AS aggregate_id,
+			jsonb_build_object(
+				'product_id', product_id,
+				'shop_id', shop_id,
+				'previous_stock', stock,
+				'stock', stock,
+				'previous_threshold', ?::int,
+				'threshold', threshold,
+				'source', source
+			) AS payload
+	`
+
+	_, err := outbox.PublishRows(ctx, tx, "product", "product.low_stock", rows, threshold, shopId, previous, threshold, previous)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::notifyLowStock - Failed to notify low stock")
+		return err
+	}
+
+	return nil
+}
+
 func (r *shopRepository) GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error) {
 	type dao struct {
 		TotalData int `db:"total_data"`
@@ -152,19 +421,20 @@ func (r *shopRepository) GetShops(ctx context.Context, req *entity.ShopsRequest)
 	)
 	resp.Items = make([]entity.ShopItem, 0, req.Paginate)
 
-	query := `
+	query := localizedShops + `
 		SELECT
 			COUNT(id) OVER() as total_data,
-			id,
-			name
-		FROM shops
+			` + strings.Join(shopColumns(req.FieldList()), ", ") + `
+		FROM localized_shops
 		WHERE
 			deleted_at IS NULL
-			AND user_id = ?
+			AND id IN (SELECT shop_id FROM shop_members WHERE user_id = ?)
+		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
 	`
 
 	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.Locale,
 		req.UserId,
 		req.Paginate,
 		req.Paginate*(req.Page-1),
@@ -186,3 +456,587 @@ func (r *shopRepository) GetShops(ctx context.Context, req *entity.ShopsRequest)
 
 	return resp, nil
 }
+
+// localizedShops exposes the shops with their name and description in the requested locale,
+// falling back to the default locale columns when there is no translation. It takes the locale as first argument.
+const localizedShops = `
+	WITH localized_shops AS (
+		SELECT
+			s.id,
+			s.user_id,
+			COALESCE(st.name, s.name) AS name,
+			COALESCE(st.description, s.description) AS description,
+			s.logo_url,
+			s.address,
+			s.city,
+			s.province,
+			s.location,
+			s.rating_average,
+			s.rating_count,
+			s.verification_status,
+			s.verification_reason,
+			s.created_at,
+			s.updated_at,
+			s.deleted_at
+		FROM shops s
+		LEFT JOIN
+			shop_translations st ON st.shop_id = s.id AND st.locale = ?
+	)
+`
+
+// shopColumns returns the columns selected by GetShops, the fields are validated
+// against a whitelist in entity.ShopsRequest.
+func shopColumns(fields []string) []string {
+	defaults := []string{"id", "name", "description", "verification_status", "verification_reason", "created_at", "updated_at"}
+
+	return fieldset.Columns(fields, defaults, "id")
+}
+
+func (r *shopRepository) GetShopTranslations(ctx context.Context, req *entity.ShopTranslationsRequest) (*entity.ShopTranslationsResponse, error) {
+	var resp = new(entity.ShopTranslationsResponse)
+	resp.Items = make([]entity.ShopTranslationItem, 0)
+
+	query := `
+		SELECT locale, name, description, terms, updated_at
+		FROM shop_translations
+		WHERE shop_id = ?
+		ORDER BY locale
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetShopTranslations - Failed to get shop translations")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) UpsertShopTranslation(ctx context.Context, req *entity.UpsertShopTranslationRequest) error {
+	query := `
+		INSERT INTO shop_translations (shop_id, locale, name, description, terms)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (shop_id, locale)
+		DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			terms = EXCLUDED.terms,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query),
+		req.ShopId,
+		req.Locale,
+		req.Name,
+		req.Description,
+		req.Terms,
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpsertShopTranslation - Failed to upsert shop translation")
+		return err
+	}
+
+	return nil
+}
+
+func (r *shopRepository) DeleteShopTranslation(ctx context.Context, req *entity.DeleteShopTranslationRequest) error {
+	query := `
+		DELETE FROM shop_translations
+		WHERE shop_id = ? AND locale = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.ShopId, req.Locale)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::DeleteShopTranslation - Failed to delete shop translation")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Terjemahan toko tidak ditemukan"))
+	}
+
+	return nil
+}
+
+func (r *shopRepository) UpdateShopAsset(ctx context.Context, req *entity.UpdateShopAssetRequest) (*entity.UpdateShopAssetResponse, error) {
+	var column string
+	switch req.Asset {
+	case "logo":
+		column = "logo_url"
+	case "banner":
+		column = "banner_url"
+	default:
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("asset", "asset harus salah satu dari logo, atau banner."))
+	}
+
+	query := `
+		UPDATE shops
+		SET
+			` + column + ` = ?,
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Url, req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShopAsset - Failed to update shop asset")
+		return nil, err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+	}
+
+	return &entity.UpdateShopAssetResponse{Url: req.Url}, nil
+}
+
+func (r *shopRepository) UpdateShopBranding(ctx context.Context, req *entity.UpdateShopBrandingRequest) (*entity.UpdateShopBrandingResponse, error) {
+	var (
+		resp        = new(entity.UpdateShopBrandingResponse)
+		socialLinks any
+	)
+
+	// a nil map would be stored as an empty object, keep the current links instead
+	if req.SocialLinks != nil {
+		socialLinks = req.SocialLinks
+	}
+
+	query := `
+		UPDATE shops
+		SET
+			accent_color = COALESCE(?, accent_color),
+			social_links = COALESCE(?::jsonb, social_links),
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.AccentColor,
+		socialLinks,
+		req.Id).Scan(&resp.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShopBranding - Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShopBranding - Failed to update shop branding")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) UpdateShopLocation(ctx context.Context, req *entity.UpdateShopLocationRequest) (*entity.UpdateShopLocationResponse, error) {
+	var resp = new(entity.UpdateShopLocationResponse)
+
+	query := `
+		UPDATE shops
+		SET
+			location = ?::geography,
+			address = ?,
+			city = ?,
+			province = ?,
+			postal_code = ?,
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.Point(),
+		req.Address,
+		req.City,
+		req.Province,
+		req.PostalCode,
+		req.Id).Scan(&resp.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShopLocation - Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShopLocation - Failed to update shop location")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) SearchShops(ctx context.Context, req *entity.SearchShopsRequest) (*entity.SearchShopsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.SearchShopItem
+	}
+
+	var (
+		resp     = new(entity.SearchShopsResponse)
+		data     = make([]dao, 0, req.Paginate)
+		distance = `NULL::float8`
+		queries  = []interface{}{req.Locale}
+	)
+	resp.Items = make([]entity.SearchShopItem, 0, req.Paginate)
+
+	if req.HasPoint() {
+		distance = `ST_Distance(location, ?::geography) / 1000`
+		queries = append(queries, req.Point())
+	}
+
+	query := localizedShops + `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			name,
+			description,
+			logo_url,
+			city,
+			province,
+			location,
+			verification_status IS NOT DISTINCT FROM 'verified' AS verified,
+			rating_average,
+			rating_count,
+			` + distance + ` AS distance_km,
+			created_at
+		FROM localized_shops
+		WHERE
+			deleted_at IS NULL
+			AND verification_status IS DISTINCT FROM 'suspended'
+	`
+
+	query, queries = searchShopsFilter(query, queries, req)
+
+	query += searchShopsOrderBy(req.Sort)
+
+	// Pagination query
+	query += ` LIMIT ? OFFSET ?`
+	queries = append(
+		queries,
+		req.Paginate, req.Paginate*(req.Page-1),
+	)
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), queries...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::SearchShops - Failed to search shops")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.SearchShopItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// searchShopsFilter appends the search and filter conditions of the shop directory.
+func searchShopsFilter(query string, queries []interface{}, req *entity.SearchShopsRequest) (string, []interface{}) {
+	/// Filter by Keyword on either name or description
+	if len(req.Keyword) > 0 {
+		query += ` AND (
+			name ILIKE ?
+			OR
+			description ILIKE ?
+		)`
+		queries = append(
+			queries,
+			"%"+req.Keyword+"%",
+			"%"+req.Keyword+"%",
+		)
+	}
+
+	/// Filter by Address
+	if len(req.City) > 0 {
+		query += ` AND lower(city) = lower(?)`
+		queries = append(queries, req.City)
+	}
+	if len(req.Province) > 0 {
+		query += ` AND lower(province) = lower(?)`
+		queries = append(queries, req.Province)
+	}
+
+	/// Filter by Radius around lat and lng
+	if req.HasPoint() {
+		query += ` AND ST_DWithin(location, ?::geography, ?::float8 * 1000)`
+		queries = append(queries, req.Point(), req.RadiusKm)
+	}
+
+	/// Filter by the Categories of the shop products
+	if categoryIds := req.CategoryIdList(); len(categoryIds) > 0 {
+		query += ` AND EXISTS (
+			SELECT 1
+			FROM products p
+			WHERE
+				p.shop_id = localized_shops.id
+				AND p.deleted_at IS NULL
+				AND p.category_id IN (
+					SELECT d.id
+					FROM categories d
+					JOIN categories a ON d.path LIKE a.path || '%'
+					WHERE a.id IN (
+						SELECT COALESCE(cr.target_id, i.id)
+						FROM unnest(?::uuid[]) AS i(id)
+						LEFT JOIN category_redirects cr ON cr.source_id = i.id
+					)
+				)
+		)`
+		queries = append(queries, pq.Array(categoryIds))
+	}
+
+	if req.Verified {
+		query += ` AND verification_status = 'verified'`
+	}
+
+	if req.MinRating > 0 {
+		query += ` AND rating_average >= ?`
+		queries = append(queries, req.MinRating)
+	}
+
+	return query, queries
+}
+
+func searchShopsOrderBy(sort string) string {
+	switch sort {
+	case "name":
+		return ` ORDER BY name ASC, id`
+	case "rating":
+		return ` ORDER BY rating_average DESC, rating_count DESC, id`
+	case "distance":
+		return ` ORDER BY distance_km ASC, id`
+	default:
+		return ` ORDER BY created_at DESC, id`
+	}
+}
+
+func (r *shopRepository) GetNearbyShops(ctx context.Context, req *entity.NearbyShopsRequest) (*entity.NearbyShopsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.NearbyShopItem
+	}
+
+	var (
+		resp = new(entity.NearbyShopsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.NearbyShopItem, 0, req.Paginate)
+
+	query := localizedShops + `,
+	origin AS (
+		SELECT ?::geography AS point
+	)
+	SELECT
+		COUNT(s.id) OVER() as total_data,
+		s.id,
+		s.name,
+		s.description,
+		s.address,
+		s.city,
+		s.location,
+		s.verification_status IS NOT DISTINCT FROM 'verified' AS verified,
+		ST_Distance(s.location, o.point) / 1000 AS distance_km
+	FROM localized_shops s
+	CROSS JOIN
+		origin o
+	WHERE
+		s.deleted_at IS NULL
+		AND s.verification_status IS DISTINCT FROM 'suspended'
+		AND s.location IS NOT NULL
+		AND ST_DWithin(s.location, o.point, ?::float8 * 1000)
+	ORDER BY distance_km ASC
+	LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.Locale,
+		req.Point(),
+		req.RadiusKm,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetNearbyShops - Failed to get nearby shops")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.NearbyShopItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *shopRepository) GetOperatingHours(ctx context.Context, shopId string) ([]entity.OperatingHourItem, error) {
+	var resp = make([]entity.OperatingHourItem, 0, 7)
+
+	query := `
+		SELECT
+			weekday,
+			to_char(opens_at, 'HH24:MI') AS opens_at,
+			to_char(closes_at, 'HH24:MI') AS closes_at
+		FROM shop_operating_hours
+		WHERE shop_id = ?
+		ORDER BY weekday
+	`
+
+	err := r.db.SelectContext(ctx, &resp, r.db.Rebind(query), shopId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::GetOperatingHours - Failed to get operating hours")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) UpdateOperatingHours(ctx context.Context, req *entity.UpdateOperatingHoursRequest) (*entity.UpdateOperatingHoursResponse, error) {
+	var resp = new(entity.UpdateOperatingHoursResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateOperatingHours - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE shops
+		SET
+			timezone = ?,
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+		RETURNING id
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query), req.Timezone, req.Id).Scan(&resp.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateOperatingHours - Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateOperatingHours - Failed to update shop timezone")
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(`DELETE FROM shop_operating_hours WHERE shop_id = ?`), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateOperatingHours - Failed to delete operating hours")
+		return nil, err
+	}
+
+	for _, h := range req.Hours {
+		query := `
+			INSERT INTO shop_operating_hours (shop_id, weekday, opens_at, closes_at)
+			VALUES (?, ?, ?, ?)
+		`
+
+		_, err = tx.ExecContext(ctx, r.db.Rebind(query), req.Id, h.Weekday, h.OpensAt, h.ClosesAt)
+		if err != nil {
+			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateOperatingHours - Failed to insert operating hours")
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateOperatingHours - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) UpdateShopVacation(ctx context.Context, req *entity.UpdateShopVacationRequest) (*entity.UpdateShopVacationResponse, error) {
+	var resp = new(entity.UpdateShopVacationResponse)
+
+	query := `
+		UPDATE shops
+		SET
+			vacation_mode = TRUE,
+			vacation_start = ?,
+			vacation_end = ?,
+			vacation_message = ?,
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.StartDate,
+		req.EndDate,
+		req.Message,
+		req.Id).Scan(&resp.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShopVacation - Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateShopVacation - Failed to update shop vacation")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) EndShopVacation(ctx context.Context, req *entity.EndShopVacationRequest) error {
+	query := `
+		UPDATE shops
+		SET
+			vacation_mode = FALSE,
+			vacation_start = NULL,
+			vacation_end = NULL,
+			vacation_message = NULL,
+			updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, r.db.Rebind(query), req.Id)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::EndShopVacation - Failed to end shop vacation")
+		return err
+	}
+
+	return nil
+}
+
+// EndExpiredVacations turns off the vacations whose end date has passed in the shop timezone.
+func (r *shopRepository) EndExpiredVacations(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE shops
+		SET
+			vacation_mode = FALSE,
+			vacation_start = NULL,
+			vacation_end = NULL,
+			vacation_message = NULL,
+			updated_at = NOW()
+		WHERE
+			vacation_mode
+			AND vacation_end < (NOW() AT TIME ZONE timezone)::date
+	`
+
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::EndExpiredVacations - Failed to end expired vacations")
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetShopStats reads the dashboard figures of the shop from the shop_stats view, a shop
+// created after the last refresh gets zeros. The rating is read live from the shop.
+func (r *shopRepository) GetShopStats(ctx context.Context, req *entity.ShopStatsRequest) (*entity.ShopStatsResponse, error) {
+	type dao struct {
+		entity.ShopProductsStats
+		entity.ShopInventoryStats
+		entity.ShopStatsResponse
+	}
+
+	var (
+		data = new(dao)
+		resp = new(entity.ShopStatsResponse)
+	)
+
+	query := `
+		SELECT
+			s.id AS shop_id,
+			COALESCE(st.product_count, 0) AS product_count,
+			COALESCE(st.in_stock_count, 0) AS in_stock_count,
+			COALESCE(st.low_stock_count, 0) AS low_stock_count,
+			COALESCE(st.out_of_stock_count, 0) AS out_of_stock_count,
+			COALESCE(st.deleted_count, 0) AS deleted_count,
+			COALESCE(st.inventory_units, 0) AS inventory_units,
+			COALESCE(st.inventory_value, 0) AS inventory_value,
+			COALESCE(st.total_views, 0) AS total_views,
+			s.rating_average,
+			s.rating_count,
+			st.refreshed_at
+		FROM shops s
+		LEFT JOIN
+			shop_stats st ON st.shop_id = s.id
+		WHERE
+			s.deleted_at IS NULL
+			AND s.id = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.ShopId).StructScan(data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Error().Err(err).Any("payload", req).Msg("repository::GetShopStats - Shop not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetShopStats - Failed to get shop stats")
+		return nil, err
+	}
+
+	*resp = data.ShopStatsResponse
+	resp.Products = data.ShopProductsStats
+	resp.Inventory = data.ShopInventoryStats
+
+	return resp, nil
+}
+
+func (r *shopRepository) RefreshShopStats(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY shop_stats`)
+	if err != nil {
+		log.Error().Err(err).Msg("repository::RefreshShopStats - Failed to refresh shop stats")
+		return err
+	}
+
+	return nil
+}
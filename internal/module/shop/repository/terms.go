@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"database/sql"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetTermsVersions also lists the terms of deleted shops, they may still be needed to settle a dispute.
+func (r *shopRepository) GetTermsVersions(ctx context.Context, req *entity.TermsVersionsRequest) (*entity.TermsVersionsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.TermsVersionItem
+	}
+
+	var (
+		resp = new(entity.TermsVersionsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.TermsVersionItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(v.version) OVER() as total_data,
+			v.version,
+			v.terms,
+			v.created_at
+		FROM shop_terms_versions v
+		WHERE
+			v.shop_id = ?
+		ORDER BY v.version DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ShopId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetTermsVersions - Failed to get terms versions")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.TermsVersionItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+func (r *shopRepository) GetTermsVersion(ctx context.Context, req *entity.GetTermsVersionRequest) (*entity.TermsVersionItem, error) {
+	var resp = new(entity.TermsVersionItem)
+
+	query := `
+		SELECT
+			version,
+			terms,
+			created_at
+		FROM shop_terms_versions
+		WHERE
+			shop_id = ?
+			AND version = ?
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query), req.ShopId, req.Version).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Versi syarat dan ketentuan tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetTermsVersion - Failed to get terms version")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RecordTermsAcceptance is idempotent, recording the same acceptance again returns the first record.
+func (r *shopRepository) RecordTermsAcceptance(ctx context.Context, req *entity.RecordTermsAcceptanceRequest) (*entity.RecordTermsAcceptanceResponse, error) {
+	var resp = new(entity.RecordTermsAcceptanceResponse)
+
+	query := `
+		INSERT INTO shop_terms_acceptances (shop_id, version, user_id, reference)
+		SELECT shop_id, version, ?::uuid, ?
+		FROM shop_terms_versions
+		WHERE
+			shop_id = ?
+			AND version = ?
+		ON CONFLICT (shop_id, version, user_id, COALESCE(reference, '')) DO UPDATE SET
+			accepted_at = shop_terms_acceptances.accepted_at
+		RETURNING id, accepted_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.UserId,
+		req.Reference,
+		req.ShopId,
+		req.Version,
+	).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Versi syarat dan ketentuan tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::RecordTermsAcceptance - Failed to record acceptance")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) GetTermsAcceptances(ctx context.Context, req *entity.TermsAcceptancesRequest) (*entity.TermsAcceptancesResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.TermsAcceptanceItem
+	}
+
+	var (
+		resp = new(entity.TermsAcceptancesResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.TermsAcceptanceItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			version,
+			user_id,
+			reference,
+			accepted_at
+		FROM shop_terms_acceptances
+		WHERE
+			shop_id = ?
+	`
+	queries := []interface{}{req.ShopId}
+
+	if req.UserId != "" {
+		query += ` AND user_id = ?`
+		queries = append(queries, req.UserId)
+	}
+
+	if req.Version > 0 {
+		query += ` AND version = ?`
+		queries = append(queries, req.Version)
+	}
+
+	query += ` ORDER BY accepted_at DESC LIMIT ? OFFSET ?`
+	queries = append(queries, req.Paginate, req.Paginate*(req.Page-1))
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query), queries...)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetTermsAcceptances - Failed to get acceptances")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.TermsAcceptanceItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
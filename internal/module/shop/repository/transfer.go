@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/outbox"
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// CreateTransfer opens an ownership transfer of the shop. Pending transfers that
+// already expired are closed first so they do not block a new one.
+func (r *shopRepository) CreateTransfer(ctx context.Context, req *entity.CreateTransferRequest) (*entity.CreateTransferResponse, error) {
+	var resp = new(entity.CreateTransferResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateTransfer - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE shop_transfers
+		SET
+			status = 'expired',
+			updated_at = NOW()
+		WHERE
+			shop_id = ?
+			AND status = 'pending'
+			AND expires_at <= NOW()
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateTransfer - Failed to expire old transfers")
+		return nil, err
+	}
+
+	query = `
+		INSERT INTO shop_transfers (shop_id, from_user_id, to_user_id, keep_previous_owner, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (shop_id) WHERE status = 'pending' DO NOTHING
+		RETURNING id, expires_at
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query),
+		req.ShopId,
+		req.FromUserId,
+		req.ToUserId,
+		req.KeepPreviousOwner,
+		req.ExpiresAt,
+	).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Toko masih memiliki pengalihan kepemilikan yang belum selesai"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateTransfer - Failed to insert transfer")
+		return nil, err
+	}
+
+	err = r.writeAuditLog(ctx, tx, req.ShopId, req.FromUserId, "ownership_transfer.initiated", map[string]any{
+		"transfer_id":         resp.Id,
+		"to_user_id":          req.ToUserId,
+		"keep_previous_owner": req.KeepPreviousOwner,
+		"expires_at":          resp.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CreateTransfer - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) CancelTransfer(ctx context.Context, req *entity.CancelTransferRequest) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CancelTransfer - Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE shop_transfers
+		SET
+			status = 'cancelled',
+			responded_at = NOW(),
+			updated_at = NOW()
+		WHERE
+			id = ?
+			AND shop_id = ?
+			AND status = 'pending'
+	`
+
+	result, err := tx.ExecContext(ctx, r.db.Rebind(query), req.Id, req.ShopId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CancelTransfer - Failed to cancel transfer")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage("Pengalihan kepemilikan tidak ditemukan"))
+	}
+
+	err = r.writeAuditLog(ctx, tx, req.ShopId, req.UserId, "ownership_transfer.cancelled", map[string]any{
+		"transfer_id": req.Id,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::CancelTransfer - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *shopRepository) GetMyTransfers(ctx context.Context, req *entity.MyTransfersRequest) (*entity.MyTransfersResponse, error) {
+	var resp = new(entity.MyTransfersResponse)
+	resp.Items = make([]entity.TransferItem, 0)
+
+	query := `
+		SELECT
+			t.id,
+			t.shop_id,
+			s.name AS shop_name,
+			t.from_user_id,
+			t.keep_previous_owner,
+			t.expires_at,
+			t.created_at
+		FROM shop_transfers t
+		JOIN
+			shops s ON s.id = t.shop_id AND s.deleted_at IS NULL
+		WHERE
+			t.to_user_id = ?
+			AND t.status = 'pending'
+			AND t.expires_at > NOW()
+		ORDER BY t.created_at DESC
+	`
+
+	err := r.db.SelectContext(ctx, &resp.Items, r.db.Rebind(query), req.UserId)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetMyTransfers - Failed to get transfers")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RespondTransfer accepts or declines a pending transfer addressed to the user.
+// Accepting moves shops.user_id and makes the recipient the owner member, all in one
+// transaction. The previous owner leaves the shop unless the transfer or the recipient
+// asked to keep them on as manager. The transfer is refused when the shop changed hands
+// after it was opened.
+func (r *shopRepository) RespondTransfer(ctx context.Context, req *entity.RespondTransferRequest) error {
+	type dao struct {
+		ShopId            string `db:"shop_id"`
+		FromUserId        string `db:"from_user_id"`
+		KeepPreviousOwner bool   `db:"keep_previous_owner"`
+	}
+
+	var (
+		transfer = new(dao)
+		status   = "declined"
+	)
+
+	if req.Accept {
+		status = "accepted"
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RespondTransfer - Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE shop_transfers t
+		SET
+			status = ?,
+			responded_at = NOW(),
+			updated_at = NOW()
+		FROM shops s
+		WHERE
+			s.id = t.shop_id
+			AND s.deleted_at IS NULL
+			AND t.id = ?
+			AND t.to_user_id = ?
+			AND t.status = 'pending'
+			AND t.expires_at > NOW()
+		RETURNING t.shop_id, t.from_user_id, t.keep_previous_owner
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query), status, req.Id, req.UserId).StructScan(transfer)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errmsg.NewCustomErrors(404, errmsg.WithMessage("Pengalihan kepemilikan tidak ditemukan atau sudah kedaluwarsa"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::RespondTransfer - Failed to update transfer")
+		return err
+	}
+
+	// the recipient has the last word on keeping the previous owner
+	if req.KeepPreviousOwner != nil {
+		transfer.KeepPreviousOwner = *req.KeepPreviousOwner
+	}
+
+	if req.Accept {
+		if err = r.moveOwnership(ctx, tx, transfer.ShopId, transfer.FromUserId, req.UserId, transfer.KeepPreviousOwner); err != nil {
+			return err
+		}
+
+		err = outbox.Publish(ctx, tx, outbox.Event{
+			AggregateType: "shop",
+			AggregateId:   transfer.ShopId,
+			EventType:     "shop.ownership_transferred",
+			Payload: map[string]any{
+				"shop_id":             transfer.ShopId,
+				"transfer_id":         req.Id,
+				"from_user_id":        transfer.FromUserId,
+				"to_user_id":          req.UserId,
+				"keep_previous_owner": transfer.KeepPreviousOwner,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	payload := map[string]any{
+		"transfer_id":  req.Id,
+		"from_user_id": transfer.FromUserId,
+		"to_user_id":   req.UserId,
+	}
+	if req.Accept {
+		payload["keep_previous_owner"] = transfer.KeepPreviousOwner
+	}
+
+	err = r.writeAuditLog(ctx, tx, transfer.ShopId, req.UserId, "ownership_transfer."+status, payload)
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RespondTransfer - Failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+func (r *shopRepository) moveOwnership(ctx context.Context, tx *sqlx.Tx, shopId, fromUserId, toUserId string, keepPreviousOwner bool) error {
+	query := `
+		UPDATE shops
+		SET
+			user_id = ?,
+			updated_at = NOW()
+		WHERE
+			id = ?
+			AND user_id = ?
+			AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, r.db.Rebind(query), toUserId, shopId, fromUserId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::moveOwnership - Failed to update shop owner")
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errmsg.NewCustomErrors(409, errmsg.WithMessage("Pemilik toko sudah berubah sejak pengalihan dibuat"))
+	}
+
+	query = `
+		DELETE FROM shop_members
+		WHERE
+			shop_id = ?
+			AND user_id = ?
+	`
+	if keepPreviousOwner {
+		query = `
+			UPDATE shop_members
+			SET
+				role = 'manager',
+				updated_at = NOW()
+			WHERE
+				shop_id = ?
+				AND user_id = ?
+		`
+	}
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), shopId, fromUserId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::moveOwnership - Failed to update previous owner membership")
+		return err
+	}
+
+	query = `
+		INSERT INTO shop_members (shop_id, user_id, role)
+		VALUES (?, ?, 'owner')
+		ON CONFLICT (shop_id, user_id) DO UPDATE SET
+			role = EXCLUDED.role,
+			updated_at = NOW()
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), shopId, toUserId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::moveOwnership - Failed to add new owner")
+		return err
+	}
+
+	// the new owner is a member now, a pending staff invitation would only downgrade them
+	query = `
+		UPDATE shop_invitations
+		SET
+			status = 'cancelled',
+			updated_at = NOW()
+		WHERE
+			shop_id = ?
+			AND user_id = ?
+			AND status = 'pending'
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), shopId, toUserId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::moveOwnership - Failed to cancel invitations")
+		return err
+	}
+
+	return nil
+}
+
+func (r *shopRepository) writeAuditLog(ctx context.Context, tx *sqlx.Tx, shopId, actorId, action string, payload map[string]any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Any("payload", payload).Msg("repository::writeAuditLog - Failed to marshal payload")
+		return err
+	}
+
+	query := `
+		INSERT INTO shop_audit_logs (shop_id, actor_id, action, payload)
+		VALUES (?, ?, ?, ?::jsonb)
+	`
+
+	_, err = tx.ExecContext(ctx, r.db.Rebind(query), shopId, actorId, action, string(data))
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Str("action", action).Msg("repository::writeAuditLog - Failed to insert audit log")
+		return err
+	}
+
+	return nil
+}
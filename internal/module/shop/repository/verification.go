@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/outbox"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// RequestVerification puts a shop that was never reviewed, or was rejected, in the review queue.
+func (r *shopRepository) RequestVerification(ctx context.Context, req *entity.RequestVerificationRequest) (*entity.VerificationResponse, error) {
+	var resp = new(entity.VerificationResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RequestVerification - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE shops
+		SET
+			verification_status = 'pending',
+			verification_reason = NULL,
+			verification_updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+			AND (verification_status IS NULL OR verification_status = 'rejected')
+		RETURNING id, verification_status, verification_reason, verification_updated_at
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query), req.ShopId).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errmsg.NewCustomErrors(409, errmsg.WithMessage("Toko sedang ditinjau, sudah terverifikasi, atau ditangguhkan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::RequestVerification - Failed to update shop")
+		return nil, err
+	}
+
+	if err = r.insertVerificationHistory(ctx, tx, req.ShopId, req.UserId, resp); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::RequestVerification - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) UpdateVerification(ctx context.Context, req *entity.UpdateVerificationRequest) (*entity.VerificationResponse, error) {
+	var resp = new(entity.VerificationResponse)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateVerification - Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE shops
+		SET
+			verification_status = ?,
+			verification_reason = ?,
+			verification_updated_at = NOW()
+		WHERE
+			deleted_at IS NULL
+			AND id = ?
+		RETURNING id, verification_status, verification_reason, verification_updated_at
+	`
+
+	err = tx.QueryRowxContext(ctx, r.db.Rebind(query), req.Status, req.Reason, req.ShopId).StructScan(resp)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateVerification - Failed to update shop")
+		return nil, err
+	}
+
+	if err = r.insertVerificationHistory(ctx, tx, req.ShopId, req.ActorId, resp); err != nil {
+		return nil, err
+	}
+
+	err = outbox.Publish(ctx, tx, outbox.Event{
+		AggregateType: "shop",
+		AggregateId:   req.ShopId,
+		EventType:     "shop.verification_changed",
+		Payload: map[string]any{
+			"shop_id": req.ShopId,
+			"status":  resp.Status,
+			"reason":  resp.Reason,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::UpdateVerification - Failed to commit transaction")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *shopRepository) insertVerificationHistory(ctx context.Context, tx *sqlx.Tx, shopId, actorId string, v *entity.VerificationResponse) error {
+	query := `
+		INSERT INTO shop_verification_history (shop_id, status, reason, actor_id)
+		VALUES (?, ?, ?, ?)
+	`
+
+	_, err := tx.ExecContext(ctx, r.db.Rebind(query), shopId, v.Status, v.Reason, actorId)
+	if err != nil {
+		log.Error().Err(err).Str("shop_id", shopId).Msg("repository::insertVerificationHistory - Failed to insert verification history")
+		return err
+	}
+
+	return nil
+}
+
+func (r *shopRepository) GetVerificationHistory(ctx context.Context, req *entity.VerificationHistoryRequest) (*entity.VerificationHistoryResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.VerificationHistoryItem
+	}
+
+	var (
+		resp = new(entity.VerificationHistoryResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.VerificationHistoryItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			status,
+			reason,
+			actor_id,
+			created_at
+		FROM shop_verification_history
+		WHERE
+			shop_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.ShopId,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetVerificationHistory - Failed to get verification history")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.VerificationHistoryItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
+
+// GetAdminShops lists the shops for moderation, the oldest review requests first.
+func (r *shopRepository) GetAdminShops(ctx context.Context, req *entity.AdminShopsRequest) (*entity.AdminShopsResponse, error) {
+	type dao struct {
+		TotalData int `db:"total_data"`
+		entity.AdminShopItem
+	}
+
+	var (
+		resp = new(entity.AdminShopsResponse)
+		data = make([]dao, 0, req.Paginate)
+	)
+	resp.Items = make([]entity.AdminShopItem, 0, req.Paginate)
+
+	query := `
+		SELECT
+			COUNT(id) OVER() as total_data,
+			id,
+			user_id,
+			name,
+			verification_status,
+			verification_reason,
+			verification_updated_at,
+			created_at
+		FROM shops
+		WHERE
+			deleted_at IS NULL
+			AND (? = '' OR verification_status = ?)
+		ORDER BY verification_updated_at ASC NULLS LAST, created_at ASC
+		LIMIT ? OFFSET ?
+	`
+
+	err := r.db.SelectContext(ctx, &data, r.db.Rebind(query),
+		req.Status,
+		req.Status,
+		req.Paginate,
+		req.Paginate*(req.Page-1),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", req).Msg("repository::GetAdminShops - Failed to get shops")
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		resp.Meta.TotalData = data[0].TotalData
+	}
+
+	for _, d := range data {
+		resp.Items = append(resp.Items, d.AdminShopItem)
+	}
+
+	resp.Meta.CountTotalPage(req.Page, req.Paginate, resp.Meta.TotalData)
+
+	return resp, nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"context"
+)
+
+func (s *shopService) FollowShop(ctx context.Context, req *entity.FollowShopRequest) error {
+	_, err := s.repo.VerifyShopExists(ctx, &entity.GetShopRequest{Id: req.ShopId})
+	if err != nil {
+		return err
+	}
+
+	return s.repo.FollowShop(ctx, req)
+}
+
+func (s *shopService) UnfollowShop(ctx context.Context, req *entity.FollowShopRequest) error {
+	return s.repo.UnfollowShop(ctx, req)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CheckPermission returns a 404 error when the shop does not exist and a 403 error
+// when the user is not a member of the shop or the member role lacks the permission.
+func (s *shopService) CheckPermission(ctx context.Context, req *entity.CheckPermissionRequest) error {
+	member, err := s.repo.GetMemberRole(ctx, req.ShopId, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == nil || !entity.RoleAllows(*member.Role, req.Permission) {
+		log.Warn().Any("payload", req).Msg("service::CheckPermission - Unauthorized")
+		return errmsg.NewCustomErrors(403, errmsg.WithMessage("Terlarang: anda tidak diizinkan untuk mengakses resource ini"))
+	}
+
+	return nil
+}
+
+func (s *shopService) GetShopMembers(ctx context.Context, req *entity.ShopMembersRequest) (*entity.ShopMembersResponse, error) {
+	return s.repo.GetShopMembers(ctx, req)
+}
+
+func (s *shopService) UpdateShopMember(ctx context.Context, req *entity.UpdateShopMemberRequest) error {
+	if err := s.checkNotOwner(ctx, req.ShopId, req.UserId); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateShopMember(ctx, req)
+}
+
+func (s *shopService) DeleteShopMember(ctx context.Context, req *entity.DeleteShopMemberRequest) error {
+	if err := s.checkNotOwner(ctx, req.ShopId, req.UserId); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteShopMember(ctx, req)
+}
+
+// checkNotOwner rejects changes to the owner membership, which only moves with the shop ownership.
+func (s *shopService) checkNotOwner(ctx context.Context, shopId, userId string) error {
+	member, err := s.repo.GetMemberRole(ctx, shopId, userId)
+	if err != nil {
+		return err
+	}
+
+	if member.Role != nil && *member.Role == entity.RoleOwner {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("user_id", "keanggotaan pemilik toko tidak dapat diubah."))
+	}
+
+	return nil
+}
+
+func (s *shopService) CreateInvitation(ctx context.Context, req *entity.CreateInvitationRequest) (*entity.CreateInvitationResponse, error) {
+	return s.repo.CreateInvitation(ctx, req)
+}
+
+func (s *shopService) GetShopInvitations(ctx context.Context, req *entity.ShopInvitationsRequest) (*entity.InvitationsResponse, error) {
+	return s.repo.GetShopInvitations(ctx, req)
+}
+
+func (s *shopService) GetMyInvitations(ctx context.Context, req *entity.MyInvitationsRequest) (*entity.InvitationsResponse, error) {
+	return s.repo.GetMyInvitations(ctx, req)
+}
+
+func (s *shopService) CancelInvitation(ctx context.Context, req *entity.CancelInvitationRequest) error {
+	return s.repo.CancelInvitation(ctx, req)
+}
+
+func (s *shopService) RespondInvitation(ctx context.Context, req *entity.RespondInvitationRequest) error {
+	return s.repo.RespondInvitation(ctx, req)
+}
+
+// isMember reports whether the user belongs to the shop, an empty user id is never a member.
+func (s *shopService) isMember(ctx context.Context, shopId, userId string) (bool, error) {
+	if userId == "" {
+		return false, nil
+	}
+
+	member, err := s.repo.GetMemberRole(ctx, shopId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return member.Role != nil, nil
+}
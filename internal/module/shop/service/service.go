@@ -1,9 +1,14 @@
 package service
 
 import (
+	"codebase-app/internal/infrastructure/config"
 	"codebase-app/internal/module/shop/entity"
 	"codebase-app/internal/module/shop/ports"
+	"codebase-app/pkg/errmsg"
+	"codebase-app/pkg/locale"
 	"context"
+
+	"github.com/rs/zerolog/log"
 )
 
 var _ ports.ShopService = &shopService{}
@@ -23,7 +28,43 @@ func (s *shopService) CreateShop(ctx context.Context, req *entity.CreateShopRequ
 }
 
 func (s *shopService) GetShop(ctx context.Context, req *entity.GetShopRequest) (*entity.GetShopResponse, error) {
-	return s.repo.GetShop(ctx, req)
+	resp, err := s.repo.GetShop(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// suspended shops and moderation reasons are only shown to the shop members
+	if resp.VerificationReason != nil || resp.IsSuspended() {
+		member, err := s.isMember(ctx, req.Id, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !member && resp.IsSuspended() {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Toko tidak ditemukan"))
+		}
+
+		if !member {
+			resp.VerificationReason = nil
+		}
+	}
+
+	resp.Location = entity.NewShopLocation(resp.Point)
+
+	if resp.VacationMode {
+		resp.Vacation = &entity.ShopVacation{
+			StartDate: resp.VacationStart,
+			EndDate:   resp.VacationEnd,
+			Message:   resp.VacationMessage,
+		}
+	}
+
+	resp.OperatingHours, err = s.repo.GetOperatingHours(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
 }
 func (s *shopService) VerifyShopExists(ctx context.Context, req *entity.GetShopRequest) (*entity.GetExistingShopResponse, error) {
 	return s.repo.VerifyShopExists(ctx, req)
@@ -33,6 +74,10 @@ func (s *shopService) DeleteShop(ctx context.Context, req *entity.DeleteShopRequ
 	return s.repo.DeleteShop(ctx, req)
 }
 
+func (s *shopService) PreviewDeleteShop(ctx context.Context, req *entity.DeleteShopRequest) (*entity.DeleteShopPreviewResponse, error) {
+	return s.repo.PreviewDeleteShop(ctx, req)
+}
+
 func (s *shopService) UpdateShop(ctx context.Context, req *entity.UpdateShopRequest) (*entity.UpdateShopResponse, error) {
 	return s.repo.UpdateShop(ctx, req)
 }
@@ -40,3 +85,108 @@ func (s *shopService) UpdateShop(ctx context.Context, req *entity.UpdateShopRequ
 func (s *shopService) GetShops(ctx context.Context, req *entity.ShopsRequest) (*entity.ShopsResponse, error) {
 	return s.repo.GetShops(ctx, req)
 }
+
+func (s *shopService) GetShopTranslations(ctx context.Context, req *entity.ShopTranslationsRequest) (*entity.ShopTranslationsResponse, error) {
+	return s.repo.GetShopTranslations(ctx, req)
+}
+
+func (s *shopService) UpsertShopTranslation(ctx context.Context, req *entity.UpsertShopTranslationRequest) error {
+	if err := locale.CheckTranslatable(req.Locale, config.Envs.App.DefaultLocale, config.Envs.App.SupportedLocales); err != nil {
+		return err
+	}
+
+	return s.repo.UpsertShopTranslation(ctx, req)
+}
+
+func (s *shopService) DeleteShopTranslation(ctx context.Context, req *entity.DeleteShopTranslationRequest) error {
+	return s.repo.DeleteShopTranslation(ctx, req)
+}
+
+func (s *shopService) UpdateShopAsset(ctx context.Context, req *entity.UpdateShopAssetRequest) (*entity.UpdateShopAssetResponse, error) {
+	return s.repo.UpdateShopAsset(ctx, req)
+}
+
+func (s *shopService) UpdateShopBranding(ctx context.Context, req *entity.UpdateShopBrandingRequest) (*entity.UpdateShopBrandingResponse, error) {
+	return s.repo.UpdateShopBranding(ctx, req)
+}
+
+func (s *shopService) UpdateShopLocation(ctx context.Context, req *entity.UpdateShopLocationRequest) (*entity.UpdateShopLocationResponse, error) {
+	return s.repo.UpdateShopLocation(ctx, req)
+}
+
+func (s *shopService) SearchShops(ctx context.Context, req *entity.SearchShopsRequest) (*entity.SearchShopsResponse, error) {
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("lat", "lat dan lng harus diisi bersamaan."))
+	}
+
+	if req.Sort == "distance" && !req.HasPoint() {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("sort", "sort distance membutuhkan lat dan lng."))
+	}
+
+	resp, err := s.repo.SearchShops(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Items {
+		resp.Items[i].Location = entity.NewShopLocation(resp.Items[i].Point)
+	}
+
+	return resp, nil
+}
+
+func (s *shopService) GetNearbyShops(ctx context.Context, req *entity.NearbyShopsRequest) (*entity.NearbyShopsResponse, error) {
+	resp, err := s.repo.GetNearbyShops(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Items {
+		resp.Items[i].Location = entity.NewShopLocation(resp.Items[i].Point)
+	}
+
+	return resp, nil
+}
+
+func (s *shopService) UpdateOperatingHours(ctx context.Context, req *entity.UpdateOperatingHoursRequest) (*entity.UpdateOperatingHoursResponse, error) {
+	seen := make(map[int]bool, len(req.Hours))
+	for _, h := range req.Hours {
+		if seen[h.Weekday] {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("hours", "setiap hari hanya boleh memiliki satu jam operasional."))
+		}
+		seen[h.Weekday] = true
+
+		// a closing time before the opening time closes the next day, ex: 20:00 - 02:00
+		if h.ClosesAt == h.OpensAt {
+			return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("hours", "jam tutup tidak boleh sama dengan jam buka."))
+		}
+	}
+
+	return s.repo.UpdateOperatingHours(ctx, req)
+}
+
+func (s *shopService) UpdateShopVacation(ctx context.Context, req *entity.UpdateShopVacationRequest) (*entity.UpdateShopVacationResponse, error) {
+	// both are YYYY-MM-DD, so they compare as strings
+	if req.StartDate != nil && req.EndDate < *req.StartDate {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("end_date", "tanggal selesai harus sama dengan atau setelah tanggal mulai."))
+	}
+
+	return s.repo.UpdateShopVacation(ctx, req)
+}
+
+func (s *shopService) EndShopVacation(ctx context.Context, req *entity.EndShopVacationRequest) error {
+	return s.repo.EndShopVacation(ctx, req)
+}
+
+func (s *shopService) EndExpiredVacations(ctx context.Context) error {
+	ended, err := s.repo.EndExpiredVacations(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ended > 0 {
+		log.Info().Int64("shops", ended).Msg("service::EndExpiredVacations - Ended expired vacations")
+	}
+
+	return nil
+}
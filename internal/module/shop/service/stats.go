@@ -0,0 +1,14 @@
+package service
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"context"
+)
+
+func (s *shopService) GetShopStats(ctx context.Context, req *entity.ShopStatsRequest) (*entity.ShopStatsResponse, error) {
+	return s.repo.GetShopStats(ctx, req)
+}
+
+func (s *shopService) RefreshShopStats(ctx context.Context) error {
+	return s.repo.RefreshShopStats(ctx)
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"context"
+)
+
+func (s *shopService) GetTermsVersions(ctx context.Context, req *entity.TermsVersionsRequest) (*entity.TermsVersionsResponse, error) {
+	return s.repo.GetTermsVersions(ctx, req)
+}
+
+func (s *shopService) GetTermsVersion(ctx context.Context, req *entity.GetTermsVersionRequest) (*entity.TermsVersionItem, error) {
+	return s.repo.GetTermsVersion(ctx, req)
+}
+
+func (s *shopService) RecordTermsAcceptance(ctx context.Context, req *entity.RecordTermsAcceptanceRequest) (*entity.RecordTermsAcceptanceResponse, error) {
+	return s.repo.RecordTermsAcceptance(ctx, req)
+}
+
+func (s *shopService) GetTermsAcceptances(ctx context.Context, req *entity.TermsAcceptancesRequest) (*entity.TermsAcceptancesResponse, error) {
+	return s.repo.GetTermsAcceptances(ctx, req)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"time"
+)
+
+func (s *shopService) CreateTransfer(ctx context.Context, req *entity.CreateTransferRequest) (*entity.CreateTransferResponse, error) {
+	if req.ToUserId == req.FromUserId {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("to_user_id", "to user id tidak boleh pemilik toko saat ini."))
+	}
+
+	req.ExpiresAt = time.Now().Add(time.Duration(config.Envs.Shop.TransferExpiry) * time.Hour)
+
+	return s.repo.CreateTransfer(ctx, req)
+}
+
+func (s *shopService) CancelTransfer(ctx context.Context, req *entity.CancelTransferRequest) error {
+	return s.repo.CancelTransfer(ctx, req)
+}
+
+func (s *shopService) GetMyTransfers(ctx context.Context, req *entity.MyTransfersRequest) (*entity.MyTransfersResponse, error) {
+	return s.repo.GetMyTransfers(ctx, req)
+}
+
+func (s *shopService) RespondTransfer(ctx context.Context, req *entity.RespondTransferRequest) error {
+	return s.repo.RespondTransfer(ctx, req)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"codebase-app/internal/module/shop/entity"
+	"codebase-app/pkg/errmsg"
+	"context"
+	"strings"
+)
+
+func (s *shopService) RequestVerification(ctx context.Context, req *entity.RequestVerificationRequest) (*entity.VerificationResponse, error) {
+	return s.repo.RequestVerification(ctx, req)
+}
+
+// UpdateVerification requires a reason when rejecting or suspending a shop, the owner sees it.
+func (s *shopService) UpdateVerification(ctx context.Context, req *entity.UpdateVerificationRequest) (*entity.VerificationResponse, error) {
+	if req.Reason != nil {
+		reason := strings.TrimSpace(*req.Reason)
+		req.Reason = &reason
+		if reason == "" {
+			req.Reason = nil
+		}
+	}
+
+	if req.Reason == nil && (req.Status == entity.VerificationRejected || req.Status == entity.VerificationSuspended) {
+		return nil, errmsg.NewCustomErrors(400, errmsg.WithErrors("reason", "reason wajib diisi untuk status rejected dan suspended."))
+	}
+
+	return s.repo.UpdateVerification(ctx, req)
+}
+
+func (s *shopService) GetVerificationHistory(ctx context.Context, req *entity.VerificationHistoryRequest) (*entity.VerificationHistoryResponse, error) {
+	return s.repo.GetVerificationHistory(ctx, req)
+}
+
+func (s *shopService) GetAdminShops(ctx context.Context, req *entity.AdminShopsRequest) (*entity.AdminShopsResponse, error) {
+	return s.repo.GetAdminShops(ctx, req)
+}
@@ -1,9 +1,13 @@
 package route
 
 import (
+	"codebase-app/internal/infrastructure/config"
+	"codebase-app/internal/middleware"
 	handlerCategory "codebase-app/internal/module/category/handler/rest"
 	handlerProduct "codebase-app/internal/module/product/handler/rest"
+	handlerQuestion "codebase-app/internal/module/question/handler/rest"
 	handlerShop "codebase-app/internal/module/shop/handler/rest"
+	"codebase-app/pkg/jwthandler"
 	"codebase-app/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
@@ -12,12 +16,27 @@ import (
 
 func SetupRoutes(app *fiber.App) {
 	var (
-		api = app.Group("")
+		api = app.Group("", middleware.Locale)
 	)
 
+	// uploads kept on the local disk when no object storage is configured
+	app.Static("/storage/public", config.Envs.App.LocalStoragePublicPath)
+
+	// public keys of the access tokens, in the plain JWKS format other services expect
+	app.Get("/.well-known/jwks.json", func(c *fiber.Ctx) error {
+		set, err := jwthandler.PublicJWKS()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(response.Error(err))
+		}
+
+		c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(set)
+	})
+
 	handlerCategory.NewCategoryHandler().Register(api)
 	handlerShop.NewShopHandler().Register(api)
 	handlerProduct.NewProductHandler().Register(api)
+	handlerQuestion.NewQuestionHandler().Register(api)
 
 	// fallback route
 	app.Use(func(c *fiber.Ctx) error {
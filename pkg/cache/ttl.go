@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// TTL is an in-memory cache whose entries expire a fixed duration after they are set.
+// Expired entries are swept on write, at most once per ttl.
+type TTL[K comparable, V any] struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	items     map[K]entry[V]
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func NewTTL[K comparable, V any](ttl time.Duration) *TTL[K, V] {
+	return &TTL[K, V]{
+		ttl:   ttl,
+		items: make(map[K]entry[V]),
+		now:   time.Now,
+	}
+}
+
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok || !c.now().Before(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for k, e := range c.items {
+			if !now.Before(e.expiresAt) {
+				delete(c.items, k)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.items[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// Clear drops every entry, use it when the cached values can no longer be trusted.
+func (c *TTL[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]entry[V])
+}
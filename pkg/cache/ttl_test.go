@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTL(t *testing.T) {
+	var (
+		now = time.Date(2024, 9, 6, 8, 0, 0, 0, time.UTC)
+		c   = NewTTL[string, int](time.Minute)
+	)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+
+	// the expired entry is swept by the next write
+	c.Set("b", 2)
+	assert.Len(t, c.items, 1)
+
+	c.Clear()
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+}
@@ -171,6 +171,27 @@ func errorValidationHandler[T any](err error, payload *T) (int, map[string][]str
 			oneOfValues[len(oneOfValues)-1] = "atau " + oneOfValues[len(oneOfValues)-1]
 			oneOfValuesStr := strings.Join(oneOfValues, ", ")
 			message = fmt.Sprintf("%s harus salah satu dari %s.", fieldInMsg, oneOfValuesStr)
+		case "uuid":
+			// message = fmt.Sprintf("%s is not a valid UUID.", fieldInMsg)
+			message = fmt.Sprintf("%s bukan UUID yang valid.", fieldInMsg)
+		case "uuid_csv":
+			// message = fmt.Sprintf("%s must be a comma separated list of valid UUIDs.", fieldInMsg)
+			message = fmt.Sprintf("%s harus berupa daftar UUID yang valid dipisahkan koma.", fieldInMsg)
+		case "csv_oneof":
+			// message = fmt.Sprintf("%s may only contain %s.", fieldInMsg, err.Param())
+			message = fmt.Sprintf("%s hanya boleh berisi %s.", fieldInMsg, strings.Join(strings.Fields(err.Param()), ", "))
+		case "timezone":
+			// message = fmt.Sprintf("%s is not a valid timezone (Ex: Asia/Jakarta).", fieldInMsg)
+			message = fmt.Sprintf("%s bukan zona waktu yang valid (Contoh: Asia/Jakarta).", fieldInMsg)
+		case "hexcolor":
+			// message = fmt.Sprintf("%s must be a hex color, ex: #ff5722.", fieldInMsg)
+			message = fmt.Sprintf("%s harus berupa warna hex, contoh: #ff5722.", fieldInMsg)
+		case "url":
+			// message = fmt.Sprintf("%s is not a valid URL.", fieldInMsg)
+			message = fmt.Sprintf("%s bukan URL yang valid.", fieldInMsg)
+		case "slug":
+			// message = fmt.Sprintf("%s may only contain lowercase letters, digits and hyphens.", fieldInMsg)
+			message = fmt.Sprintf("%s hanya boleh berisi huruf kecil, angka dan tanda hubung.", fieldInMsg)
 		case "unique_in_slice":
 			// message = fmt.Sprintf("%s elements must be unique.", fieldInMsg)
 			message = fmt.Sprintf("elemen %s harus unik.", fieldInMsg)
@@ -0,0 +1,71 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Parse splits a comma separated fields value, ex: "id, name,price" => [id name price]
+func Parse(value string) []string {
+	fields := make([]string, 0)
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// Columns returns the columns to select for the requested fields.
+// Every field must already be validated against a whitelist because the result is put into the query as is.
+// When no field is requested all defaults are returned; required columns are always selected.
+func Columns(fields, defaults []string, required ...string) []string {
+	if len(fields) == 0 {
+		return defaults
+	}
+
+	var (
+		seen    = make(map[string]bool, len(fields)+len(required))
+		columns = make([]string, 0, len(fields)+len(required))
+	)
+
+	for _, list := range [][]string{required, fields} {
+		for _, column := range list {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	return columns
+}
+
+// Project converts the items into maps holding only the given JSON fields.
+func Project[T any](items []T, fields ...string) ([]map[string]any, error) {
+	projected := make([]map[string]any, 0, len(items))
+
+	for _, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		var full map[string]any
+		if err := json.Unmarshal(b, &full); err != nil {
+			return nil, err
+		}
+
+		m := make(map[string]any, len(fields))
+		for _, field := range fields {
+			if value, ok := full[field]; ok {
+				m[field] = value
+			}
+		}
+
+		projected = append(projected, m)
+	}
+
+	return projected, nil
+}
@@ -0,0 +1,28 @@
+package fieldset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumns(t *testing.T) {
+	defaults := []string{"id", "name", "price", "stock"}
+
+	assert.Equal(t, defaults, Columns(nil, defaults, "id"))
+	assert.Equal(t, []string{"id", "name"}, Columns([]string{"name"}, defaults, "id"))
+	assert.Equal(t, []string{"id", "price"}, Columns([]string{"id", "price"}, defaults, "id"))
+}
+
+func TestProject(t *testing.T) {
+	type item struct {
+		Id    string `json:"id"`
+		Name  string `json:"name"`
+		Price int    `json:"price"`
+	}
+
+	projected, err := Project([]item{{Id: "1", Name: "kaos", Price: 0}}, "id", "price")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"id": "1", "price": float64(0)}}, projected)
+}
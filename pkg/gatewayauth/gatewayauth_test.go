@@ -0,0 +1,29 @@
+package gatewayauth
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	var (
+		secret = []byte("secret")
+		userId = "08362b22-f51d-40b1-a16b-49af90d561d9"
+		now    = time.Date(2024, 9, 22, 8, 0, 0, 0, time.UTC)
+		ts     = strconv.FormatInt(now.Unix(), 10)
+		sig    = Sign(secret, userId, "user", now)
+	)
+
+	assert.NoError(t, Verify(secret, userId, "user", ts, sig, time.Minute, now))
+	assert.NoError(t, Verify(secret, userId, "user", ts, sig, time.Minute, now.Add(time.Minute)))
+
+	assert.ErrorIs(t, Verify(secret, userId, "user", ts, "", time.Minute, now), ErrMissingSignature)
+	assert.ErrorIs(t, Verify(secret, userId, "admin", ts, sig, time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify([]byte("other"), userId, "user", ts, sig, time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, userId, "user", ts, "zz", time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, userId, "user", ts, sig, time.Minute, now.Add(2*time.Minute)), ErrStaleSignature)
+	assert.ErrorIs(t, Verify(secret, userId, "user", ts, sig, time.Minute, now.Add(-2*time.Minute)), ErrStaleSignature)
+}
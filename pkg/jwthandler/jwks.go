@@ -0,0 +1,207 @@
+package jwthandler
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// JWK is a public key in the RFC 7517 JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK encodes an RSA or ECDSA P-256 public key.
+func NewJWK(kid string, pub crypto.PublicKey) (JWK, error) {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return JWK{}, ErrUnsupportedKey
+		}
+
+		// coordinates are padded to the curve size
+		x, y := make([]byte, 32), make([]byte, 32)
+		k.X.FillBytes(x)
+		k.Y.FillBytes(y)
+
+		return JWK{
+			Kty: "EC",
+			Kid: kid,
+			Use: "sig",
+			Alg: "ES256",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(x),
+			Y:   base64.RawURLEncoding.EncodeToString(y),
+		}, nil
+	default:
+		return JWK{}, ErrUnsupportedKey
+	}
+}
+
+// PublicKey decodes the key, only RSA and EC P-256 signature keys are supported.
+func (j JWK) PublicKey() (crypto.PublicKey, error) {
+	if j.Use != "" && j.Use != "sig" {
+		return nil, ErrUnsupportedKey
+	}
+
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: decode jwk n: %w", err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: decode jwk e: %w", err)
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if j.Crv != "P-256" {
+			return nil, ErrUnsupportedKey
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: decode jwk x: %w", err)
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: decode jwk y: %w", err)
+		}
+
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, ErrUnsupportedKey
+		}
+
+		return pub, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// jwksMinRefresh keeps tokens with made up kids from hammering the JWKS endpoint.
+const jwksMinRefresh = 30 * time.Second
+
+// jwksClient caches the keys served by a JWKS endpoint. The cache is refreshed once it is
+// older than ttl, or sooner when a token carries a kid it does not know yet.
+type jwksClient struct {
+	url    string
+	ttl    time.Duration
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func newJWKSClient(url string, ttl time.Duration) *jwksClient {
+	return &jwksClient{
+		url:    url,
+		ttl:    ttl,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (c *jwksClient) key(kid string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	age := time.Since(c.fetchedAt)
+	if key, ok := c.keys[kid]; ok && age < c.ttl {
+		return key, nil
+	}
+
+	if c.keys == nil || age >= jwksMinRefresh {
+		if err := c.refresh(); err != nil {
+			// keep verifying with the keys we have until the endpoint is back
+			log.Warn().Err(err).Str("url", c.url).Msg("jwthandler::jwksClient - Failed to refresh keys")
+		}
+	}
+
+	if key, ok := c.keys[kid]; ok {
+		return key, nil
+	}
+
+	return nil, ErrUnknownKid
+}
+
+func (c *jwksClient) refresh() error {
+	c.fetchedAt = time.Now()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwthandler: jwks endpoint returned %d", resp.StatusCode)
+	}
+
+	var set JWKSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("jwthandler: decode jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kid == "" {
+			continue
+		}
+
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			log.Warn().Err(err).Str("kid", jwk.Kid).Msg("jwthandler::jwksClient - Skipping key")
+			continue
+		}
+		keys[jwk.Kid] = pub
+	}
+
+	c.keys = keys
+	return nil
+}
@@ -0,0 +1,72 @@
+package jwthandler
+
+import (
+	"codebase-app/internal/infrastructure/config"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrStaleGatewayToken = errors.New("jwthandler: gateway token issued outside the allowed window")
+
+// GenerateGatewayToken signs the identity the gateway forwards, the token lives for GATEWAY_MAX_SKEW.
+func GenerateGatewayToken(userId, role string) (string, error) {
+	now := time.Now().UTC()
+	exp := now.Add(time.Second * time.Duration(config.Envs.Gateway.MaxSkew))
+
+	claims := GatewayClaims{
+		UserId: userId,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user",
+			Issuer:    "gateway",
+			ExpiresAt: jwt.NewNumericDate(exp),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
+	tokenString, err := token.SignedString([]byte(config.Envs.Gateway.JwtSecret))
+	if err != nil {
+		log.Error().Err(err).Msg("jwthandler::GenerateGatewayToken - Error while signing token")
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// ParseGatewayToken verifies a gateway token, it must be issued within GATEWAY_MAX_SKEW of now.
+func ParseGatewayToken(tokenString string) (*GatewayClaims, error) {
+	var (
+		claims  = &GatewayClaims{}
+		maxSkew = time.Second * time.Duration(config.Envs.Gateway.MaxSkew)
+	)
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(config.Envs.Gateway.JwtSecret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(maxSkew),
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("jwthandler::ParseGatewayToken - Error while parsing token")
+		return nil, err
+	}
+
+	if !token.Valid {
+		log.Error().Msg("jwthandler::ParseGatewayToken - Invalid token")
+		return nil, jwt.ErrTokenUnverifiable
+	}
+
+	if claims.IssuedAt == nil || time.Since(claims.IssuedAt.Time).Abs() > maxSkew {
+		log.Error().Msg("jwthandler::ParseGatewayToken - Stale token")
+		return nil, ErrStaleGatewayToken
+	}
+
+	return claims, nil
+}
@@ -2,13 +2,96 @@ package jwthandler
 
 import (
 	"codebase-app/internal/infrastructure/config"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog/log"
 )
 
+var (
+	keysOnce sync.Once
+	keys     *KeySet
+	keysErr  error
+)
+
+// LoadKeys builds the key set from the JWT_* settings once. The server calls it at startup,
+// so that a missing or broken key file stops the boot instead of failing every request.
+func LoadKeys() error {
+	keysOnce.Do(func() {
+		keys, keysErr = loadKeySet()
+	})
+
+	return keysErr
+}
+
+func loadKeySet() (*KeySet, error) {
+	guard := config.Envs.Guard
+
+	alg := strings.ToUpper(guard.JwtAlgorithm)
+	if alg == "" || alg == jwt.SigningMethodHS256.Alg() {
+		return NewHmacKeySet([]byte(guard.JwtPrivateKey)), nil
+	}
+
+	var signingPEM []byte
+	if guard.JwtSigningKeyFile != "" {
+		data, err := os.ReadFile(guard.JwtSigningKeyFile)
+		if err != nil {
+			log.Error().Err(err).Str("file", guard.JwtSigningKeyFile).Msg("jwthandler::LoadKeys - Error while reading signing key")
+			return nil, err
+		}
+		signingPEM = data
+	}
+
+	// entries are "kid=path", or a bare path for a key known by its thumbprint
+	verifyKeys := make([]VerifyKey, 0, len(guard.JwtVerifyKeyFiles))
+	for _, entry := range guard.JwtVerifyKeyFiles {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		var kid, file = "", entry
+		if k, f, ok := strings.Cut(entry, "="); ok {
+			kid, file = strings.TrimSpace(k), strings.TrimSpace(f)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			log.Error().Err(err).Str("file", file).Msg("jwthandler::LoadKeys - Error while reading verification key")
+			return nil, err
+		}
+		verifyKeys = append(verifyKeys, VerifyKey{Kid: kid, PEM: data})
+	}
+
+	ks, err := NewKeySet(alg, signingPEM, guard.JwtSigningKeyId, verifyKeys...)
+	if err != nil {
+		log.Error().Err(err).Str("alg", alg).Msg("jwthandler::LoadKeys - Error while loading keys")
+		return nil, err
+	}
+
+	if guard.JwksUrl != "" {
+		ks.remote = newJWKSClient(guard.JwksUrl, time.Second*time.Duration(guard.JwksCacheTTL))
+	}
+
+	return ks, nil
+}
+
+// PublicJWKS returns the verification keys served on /.well-known/jwks.json, empty for HS256.
+func PublicJWKS() (JWKSet, error) {
+	if err := LoadKeys(); err != nil {
+		return JWKSet{}, err
+	}
+
+	return keys.JWKS(), nil
+}
+
 func GenerateTokenString(payload CostumClaimsPayload) (string, error) {
+	if err := LoadKeys(); err != nil {
+		return "", err
+	}
+
 	claims := CustomClaims{
 		UserId: payload.UserId,
 		Role:   payload.Role,
@@ -21,8 +104,7 @@ func GenerateTokenString(payload CostumClaimsPayload) (string, error) {
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	tokenString, err := token.SignedString([]byte(config.Envs.Guard.JwtPrivateKey))
+	tokenString, err := keys.Sign(&claims)
 	if err != nil {
 		log.Error().Err(err).Msg("jwthandler::GenerateTokenString - Error while signing token")
 		return "", err
@@ -32,10 +114,12 @@ func GenerateTokenString(payload CostumClaimsPayload) (string, error) {
 }
 
 func ParseTokenString(tokenString string) (*CustomClaims, error) {
+	if err := LoadKeys(); err != nil {
+		return nil, err
+	}
+
 	claims := &CustomClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.Envs.Guard.JwtPrivateKey), nil
-	})
+	token, err := keys.Parse(tokenString, claims)
 	if err != nil {
 		log.Error().Err(err).Msg("jwthandler::ParseTokenString - Error while parsing token")
 		return nil, err
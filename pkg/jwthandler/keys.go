@@ -0,0 +1,270 @@
+package jwthandler
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnknownKid        = errors.New("jwthandler: unknown key id")
+	ErrUnsupportedKey    = errors.New("jwthandler: unsupported key, expected RSA or ECDSA P-256")
+	ErrUnsupportedMethod = errors.New("jwthandler: unsupported signing method, expected HS256, RS256 or ES256")
+)
+
+// KeySet signs tokens with a single key and verifies them with every key it knows, looked up
+// by the kid header. Rotating a key means signing with the new one while the old public key
+// stays in the verification keys until the tokens it signed have expired.
+type KeySet struct {
+	method  jwt.SigningMethod
+	signKid string
+	signKey any
+
+	verifyKeys map[string]crypto.PublicKey
+
+	// remote looks up the kids that are not known locally, it may be nil.
+	remote *jwksClient
+}
+
+// NewHmacKeySet signs and verifies HS256 tokens without kid with the shared secret.
+func NewHmacKeySet(secret []byte) *KeySet {
+	return &KeySet{
+		method:  jwt.SigningMethodHS256,
+		signKey: secret,
+	}
+}
+
+// VerifyKey is a PEM public key still accepted for verification, ex: the previous signing key.
+// Kid is the kid the key signed its tokens with, its JWK thumbprint when empty.
+type VerifyKey struct {
+	Kid string
+	PEM []byte
+}
+
+// NewKeySet signs RS256 or ES256 tokens with the PEM private key, under kid or its JWK
+// thumbprint when kid is empty. The verification keys are accepted as well.
+// A set without private key only verifies tokens.
+func NewKeySet(alg string, signingPEM []byte, kid string, verifyKeys ...VerifyKey) (*KeySet, error) {
+	ks := &KeySet{verifyKeys: make(map[string]crypto.PublicKey)}
+
+	switch alg {
+	case jwt.SigningMethodRS256.Alg():
+		ks.method = jwt.SigningMethodRS256
+	case jwt.SigningMethodES256.Alg():
+		ks.method = jwt.SigningMethodES256
+	default:
+		return nil, ErrUnsupportedMethod
+	}
+
+	if len(signingPEM) > 0 {
+		signer, err := ParsePrivateKeyPEM(signingPEM)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkKeyMethod(signer.Public(), ks.method); err != nil {
+			return nil, err
+		}
+
+		if kid == "" {
+			if kid, err = Thumbprint(signer.Public()); err != nil {
+				return nil, err
+			}
+		}
+
+		ks.signKid = kid
+		ks.signKey = signer
+		ks.verifyKeys[kid] = signer.Public()
+	}
+
+	for _, vk := range verifyKeys {
+		pub, err := ParsePublicKeyPEM(vk.PEM)
+		if err != nil {
+			return nil, err
+		}
+
+		kid := vk.Kid
+		if kid == "" {
+			if kid, err = Thumbprint(pub); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, ok := ks.verifyKeys[kid]; ok {
+			return nil, fmt.Errorf("jwthandler: duplicate key id %q", kid)
+		}
+
+		ks.verifyKeys[kid] = pub
+	}
+
+	return ks, nil
+}
+
+// Sign returns the signed token of the claims, with the kid header for asymmetric keys.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.signKey == nil {
+		return "", errors.New("jwthandler: no signing key configured")
+	}
+
+	token := jwt.NewWithClaims(ks.method, claims)
+	if ks.signKid != "" {
+		token.Header["kid"] = ks.signKid
+	}
+
+	return token.SignedString(ks.signKey)
+}
+
+// Parse verifies the token and decodes it into claims. Only the signing method of the set is
+// accepted, so that a public key can never be used as an HMAC secret.
+func (ks *KeySet) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, ks.keyFunc, jwt.WithValidMethods([]string{ks.method.Alg()}))
+}
+
+func (ks *KeySet) keyFunc(token *jwt.Token) (any, error) {
+	if ks.method == jwt.SigningMethodHS256 {
+		return ks.signKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, ErrUnknownKid
+	}
+
+	if key, ok := ks.verifyKeys[kid]; ok {
+		return key, nil
+	}
+
+	if ks.remote != nil {
+		return ks.remote.key(kid)
+	}
+
+	return nil, ErrUnknownKid
+}
+
+// JWKS returns the public verification keys, empty for HS256.
+func (ks *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(ks.verifyKeys))}
+
+	for kid, pub := range ks.verifyKeys {
+		jwk, err := NewJWK(kid, pub)
+		if err != nil {
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+
+	return set
+}
+
+// ParsePrivateKeyPEM reads an RSA or ECDSA private key in PKCS#8, PKCS#1 or SEC 1 form.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwthandler: no PEM block found")
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwthandler: parse private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// ParsePublicKeyPEM reads an RSA or ECDSA public key in PKIX or PKCS#1 form.
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwthandler: no PEM block found")
+	}
+
+	var (
+		key any
+		err error
+	)
+	if block.Type == "RSA PUBLIC KEY" {
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwthandler: parse public key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return k, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, ErrUnsupportedKey
+		}
+		return k, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// Thumbprint returns the RFC 7638 SHA-256 thumbprint of the public key, used as its kid.
+func Thumbprint(pub crypto.PublicKey) (string, error) {
+	jwk, err := NewJWK("", pub)
+	if err != nil {
+		return "", err
+	}
+
+	// the required members only, in lexicographic order
+	var canonical string
+	switch jwk.Kty {
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	case "EC":
+		canonical = fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, jwk.Crv, jwk.X, jwk.Y)
+	}
+
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func checkKeyMethod(pub crypto.PublicKey, method jwt.SigningMethod) error {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		if method == jwt.SigningMethodRS256 {
+			return nil
+		}
+	case *ecdsa.PublicKey:
+		if method == jwt.SigningMethodES256 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("jwthandler: signing key does not match %s", method.Alg())
+}
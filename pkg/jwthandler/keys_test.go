@@ -0,0 +1,171 @@
+package jwthandler
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func privatePEM(t *testing.T, key any) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func publicPEM(t *testing.T, key any) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func claims() *CustomClaims {
+	return &CustomClaims{
+		UserId: "08362b22-f51d-40b1-a16b-49af90d561d9",
+		Role:   "user",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+func TestKeySetRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	for alg, key := range map[string]any{"RS256": rsaKey, "ES256": ecKey} {
+		ks, err := NewKeySet(alg, privatePEM(t, key), "")
+		require.NoError(t, err, alg)
+
+		token, err := ks.Sign(claims())
+		require.NoError(t, err, alg)
+
+		parsed := &CustomClaims{}
+		_, err = ks.Parse(token, parsed)
+		require.NoError(t, err, alg)
+		assert.Equal(t, "user", parsed.Role)
+
+		jwks := ks.JWKS()
+		require.Len(t, jwks.Keys, 1)
+		assert.Equal(t, ks.signKid, jwks.Keys[0].Kid)
+	}
+
+	_, err = NewKeySet("ES256", privatePEM(t, rsaKey), "")
+	assert.Error(t, err)
+}
+
+func TestKeySetRotation(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	before, err := NewKeySet("ES256", privatePEM(t, oldKey), "")
+	require.NoError(t, err)
+	token, err := before.Sign(claims())
+	require.NoError(t, err)
+
+	// the old public key is kept for verification after the rotation
+	after, err := NewKeySet("ES256", privatePEM(t, newKey), "2024-09", VerifyKey{PEM: publicPEM(t, &oldKey.PublicKey)})
+	require.NoError(t, err)
+	_, err = after.Parse(token, &CustomClaims{})
+	assert.NoError(t, err)
+	assert.Len(t, after.JWKS().Keys, 2)
+
+	// without it the token is refused
+	dropped, err := NewKeySet("ES256", privatePEM(t, newKey), "2024-09")
+	require.NoError(t, err)
+	_, err = dropped.Parse(token, &CustomClaims{})
+	assert.ErrorIs(t, err, ErrUnknownKid)
+}
+
+func TestKeySetRotationWithCustomKid(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	before, err := NewKeySet("RS256", privatePEM(t, oldKey), "2024-09")
+	require.NoError(t, err)
+	token, err := before.Sign(claims())
+	require.NoError(t, err)
+
+	after, err := NewKeySet("RS256", privatePEM(t, newKey), "2024-10",
+		VerifyKey{Kid: "2024-09", PEM: publicPEM(t, &oldKey.PublicKey)},
+	)
+	require.NoError(t, err)
+
+	_, err = after.Parse(token, &CustomClaims{})
+	assert.NoError(t, err)
+
+	kids := make([]string, 0)
+	for _, k := range after.JWKS().Keys {
+		kids = append(kids, k.Kid)
+	}
+	assert.Equal(t, []string{"2024-09", "2024-10"}, kids)
+
+	// the previous key cannot take the kid of the signing key
+	_, err = NewKeySet("RS256", privatePEM(t, newKey), "2024-10",
+		VerifyKey{Kid: "2024-10", PEM: publicPEM(t, &oldKey.PublicKey)},
+	)
+	assert.Error(t, err)
+}
+
+func TestKeySetRemoteJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer, err := NewKeySet("RS256", privatePEM(t, key), "")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(issuer.JWKS())
+	}))
+	defer server.Close()
+
+	verifier, err := NewKeySet("RS256", nil, "")
+	require.NoError(t, err)
+	verifier.remote = newJWKSClient(server.URL, time.Minute)
+
+	token, err := issuer.Sign(claims())
+	require.NoError(t, err)
+
+	_, err = verifier.Parse(token, &CustomClaims{})
+	assert.NoError(t, err)
+}
+
+func TestKeySetRejectsOtherMethods(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ks, err := NewKeySet("RS256", privatePEM(t, key), "")
+	require.NoError(t, err)
+
+	// an HS256 token signed with the public key must not pass as RS256
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+	forged.Header["kid"] = ks.signKid
+	token, err := forged.SignedString(publicPEM(t, &key.PublicKey))
+	require.NoError(t, err)
+
+	_, err = ks.Parse(token, &CustomClaims{})
+	assert.Error(t, err)
+
+	hmac := NewHmacKeySet([]byte("secret"))
+	rsToken, err := ks.Sign(claims())
+	require.NoError(t, err)
+	_, err = hmac.Parse(rsToken, &CustomClaims{})
+	assert.Error(t, err)
+}
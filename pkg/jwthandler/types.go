@@ -29,3 +29,9 @@ type CostumClaimsPayloadWs struct {
 	Role            string    `json:"role"`
 	TokenExpiration time.Time `json:"token_expiration"`
 }
+
+type GatewayClaims struct {
+	UserId string `json:"user_id"`
+	Role   string `json:"role"`
+	jwt.RegisteredClaims
+}
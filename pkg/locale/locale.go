@@ -0,0 +1,87 @@
+package locale
+
+import (
+	"codebase-app/pkg/errmsg"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IsSupported reports whether the locale is one of the supported locales.
+func IsSupported(locale string, supported []string) bool {
+	for _, s := range supported {
+		if strings.EqualFold(locale, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Negotiate picks the first supported locale from an Accept-Language header value,
+// ex: "en-US,en;q=0.9,id;q=0.8" => "en". Regions are ignored, only the language is matched.
+func Negotiate(acceptLanguage string, supported []string) (string, bool) {
+	type tag struct {
+		lang string
+		q    float64
+	}
+
+	tags := make([]tag, 0)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		var (
+			fields = strings.Split(strings.TrimSpace(part), ";")
+			lang   = strings.ToLower(strings.TrimSpace(fields[0]))
+			q      = 1.0
+		)
+
+		if lang == "" || lang == "*" {
+			continue
+		}
+
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		if idx := strings.IndexAny(lang, "-_"); idx != -1 {
+			lang = lang[:idx]
+		}
+
+		tags = append(tags, tag{lang: lang, q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	for _, t := range tags {
+		if t.q > 0 && IsSupported(t.lang, supported) {
+			return t.lang, true
+		}
+	}
+
+	return "", false
+}
+
+// CheckTranslatable returns a 400 error when a translation can not be stored for the locale.
+// Content in the default locale lives in the base columns, and unsupported locales are never served.
+func CheckTranslatable(locale, defaultLocale string, supported []string) error {
+	if strings.EqualFold(locale, defaultLocale) {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("locale",
+			fmt.Sprintf("locale %s adalah locale bawaan, ubah data utama untuk mengganti kontennya.", defaultLocale),
+		))
+	}
+
+	if !IsSupported(locale, supported) {
+		return errmsg.NewCustomErrors(400, errmsg.WithErrors("locale",
+			fmt.Sprintf("locale harus salah satu dari %s.", strings.Join(supported, ", ")),
+		))
+	}
+
+	return nil
+}
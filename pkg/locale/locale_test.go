@@ -0,0 +1,25 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"id", "en"}
+
+	lang, ok := Negotiate("en-US,en;q=0.9,id;q=0.8", supported)
+	assert.True(t, ok)
+	assert.Equal(t, "en", lang)
+
+	lang, ok = Negotiate("fr-FR, id;q=0.5, en;q=0.7", supported)
+	assert.True(t, ok)
+	assert.Equal(t, "en", lang)
+
+	_, ok = Negotiate("fr-FR,de;q=0.9", supported)
+	assert.False(t, ok)
+
+	_, ok = Negotiate("", supported)
+	assert.False(t, ok)
+}
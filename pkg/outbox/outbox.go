@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// Event is a domain event stored in the domain_events table.
+// The events are written in the same transaction as the change that caused them,
+// and picked up from there by whatever publishes them to other services.
+type Event struct {
+	AggregateType string
+	AggregateId   string
+	EventType     string
+	Payload       any
+}
+
+func Publish(ctx context.Context, db sqlx.ExtContext, ev Event) error {
+	payload, err := json.Marshal(ev.Payload)
+	if err != nil {
+		log.Error().Err(err).Any("payload", ev).Msg("outbox::Publish - Failed to marshal payload")
+		return err
+	}
+
+	query := `
+		INSERT INTO domain_events (aggregate_type, aggregate_id, event_type, payload)
+		VALUES (?, ?, ?, ?)
+	`
+
+	_, err = db.ExecContext(ctx, db.Rebind(query),
+		ev.AggregateType,
+		ev.AggregateId,
+		ev.EventType,
+		string(payload),
+	)
+	if err != nil {
+		log.Error().Err(err).Any("payload", ev).Msg("outbox::Publish - Failed to insert domain event")
+		return err
+	}
+
+	return nil
+}
+
+// PublishRows stores one event per row returned by rows, a statement selecting the
+// aggregate_id and the jsonb payload columns, ex: an INSERT ... RETURNING. The events are
+// written by a single statement however many rows there are.
+func PublishRows(ctx context.Context, db sqlx.ExtContext, aggregateType, eventType, rows string, args ...any) (int64, error) {
+	query := `
+		WITH events AS (` + rows + `)
+		INSERT INTO domain_events (aggregate_type, aggregate_id, event_type, payload)
+		SELECT ?, aggregate_id, ?, payload
+		FROM events
+	`
+
+	result, err := db.ExecContext(ctx, db.Rebind(query), append(args, aggregateType, eventType)...)
+	if err != nil {
+		log.Error().Err(err).Str("event_type", eventType).Msg("outbox::PublishRows - Failed to insert domain events")
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
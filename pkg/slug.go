@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slugify turns s into a lowercase url slug, ex: "Handphone & Tablet" becomes "handphone-tablet".
+// Accents are dropped and any other character outside a-z and 0-9 separates words.
+// The result is empty when s holds no letter or digit at all.
+func Slugify(s string, maxLen int) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if folded, _, err := transform.String(t, s); err == nil {
+		s = folded
+	}
+
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	}) {
+		if maxLen > 0 && b.Len() == 0 && len(word) > maxLen {
+			word = word[:maxLen]
+		}
+		if maxLen > 0 && b.Len() > 0 && b.Len()+len(word)+1 > maxLen {
+			break
+		}
+		if b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(word)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,16 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "handphone-tablet", Slugify("Handphone & Tablet", 0))
+	assert.Equal(t, "cafe-creme", Slugify("  Café  Crème ", 0))
+	assert.Equal(t, "tv-4k", Slugify("TV 4K!!", 0))
+	assert.Equal(t, "", Slugify("!!!", 0))
+	assert.Equal(t, "elektronik", Slugify("Elektronik Rumah Tangga", 12))
+	assert.Equal(t, "abcde", Slugify("abcdefgh", 5))
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points at the last item of a page ordered by (created_at, id) descending.
+// Clients get it encoded and send it back untouched to fetch the next page.
+type Cursor struct {
+	CreatedAt time.Time
+	Id        string
+}
+
+func (c Cursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.Id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || !IsUUID(id) {
+		return nil, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{CreatedAt: t, Id: id}, nil
+}
+
+type CursorMeta struct {
+	Limit      int     `json:"limit"`
+	NextCursor *string `json:"next_cursor"` // nil on the last page
+}
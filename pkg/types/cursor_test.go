@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	c := Cursor{
+		CreatedAt: time.Date(2024, 9, 15, 8, 0, 0, 123456000, time.UTC),
+		Id:        "08362b22-f51d-40b1-a16b-49af90d561d9",
+	}
+
+	decoded, err := DecodeCursor(c.Encode())
+	assert.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, c.Id, decoded.Id)
+
+	invalidId := Cursor{CreatedAt: c.CreatedAt, Id: "not-a-uuid"}.Encode()
+
+	for _, value := range []string{"", "not base64!", "bm8tc2VwYXJhdG9y", "eHx5", invalidId} {
+		_, err := DecodeCursor(value)
+		assert.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
@@ -0,0 +1,30 @@
+package types
+
+import "encoding/json"
+
+// Nullable is an optional JSON field that tells an omitted value apart from an explicit null,
+// for partial updates where null clears a column and an omitted field leaves it unchanged.
+type Nullable[T any] struct {
+	Set   bool // the field was sent, null included
+	Value *T   // nil for null
+}
+
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+
+	return json.Unmarshal(data, &n.Value)
+}
+
+// Validated returns the value checked by the validator, nil when it is omitted or null.
+func (n Nullable[T]) Validated() any {
+	if n.Value == nil {
+		return nil
+	}
+
+	return *n.Value
+}
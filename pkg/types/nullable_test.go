@@ -0,0 +1,28 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullable(t *testing.T) {
+	type request struct {
+		Threshold Nullable[int] `json:"threshold"`
+	}
+
+	var omitted, null, set request
+	assert.NoError(t, json.Unmarshal([]byte(`{}`), &omitted))
+	assert.NoError(t, json.Unmarshal([]byte(`{"threshold": null}`), &null))
+	assert.NoError(t, json.Unmarshal([]byte(`{"threshold": 3}`), &set))
+
+	assert.False(t, omitted.Threshold.Set)
+	assert.True(t, null.Threshold.Set)
+	assert.Nil(t, null.Threshold.Value)
+	assert.True(t, set.Threshold.Set)
+	assert.Equal(t, 3, *set.Threshold.Value)
+
+	var invalid request
+	assert.Error(t, json.Unmarshal([]byte(`{"threshold": "3"}`), &invalid))
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMap is a string map stored in a JSONB column.
+type JSONMap map[string]string
+
+// Scan implements the sql.Scanner interface.
+func (m *JSONMap) Scan(val interface{}) error {
+	var b []byte
+	switch v := val.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type %T for JSONMap", val)
+	}
+
+	return json.Unmarshal(b, m)
+}
+
+// Value impl.
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,11 @@
+package types
+
+import "regexp"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID tells whether s is a uuid in its textual form, to check an id before it reaches
+// a uuid column where Postgres would fail the cast.
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
@@ -1,7 +1,9 @@
 package validator
 
 import (
+	"codebase-app/pkg/types"
 	"reflect"
+	"regexp"
 	"strings"
 
 	// "github.com/go-playground/locales/en"
@@ -62,6 +64,18 @@ func NewValidator() *Validator {
 	if err := v.RegisterValidation("unique_in_slice", isUniqueInSlice); err != nil {
 		log.Fatal().Err(err).Msg("Error while registering unique validator")
 	}
+	if err := v.RegisterValidation("uuid_csv", isUuidCsv); err != nil {
+		log.Fatal().Err(err).Msg("Error while registering uuid_csv validator")
+	}
+	if err := v.RegisterValidation("csv_oneof", isCsvOneOf); err != nil {
+		log.Fatal().Err(err).Msg("Error while registering csv_oneof validator")
+	}
+	if err := v.RegisterValidation("slug", isSlug); err != nil {
+		log.Fatal().Err(err).Msg("Error while registering slug validator")
+	}
+
+	// nullable fields are validated by their value, omitted and null ones count as empty
+	v.RegisterCustomTypeFunc(nullableValue, types.Nullable[int]{})
 
 	validatorCustom.validator = v
 	// validatorCustom.trans = trans
@@ -73,6 +87,14 @@ func (v *Validator) Validate(i any) error {
 	return v.validator.Struct(i)
 }
 
+func nullableValue(field reflect.Value) any {
+	if n, ok := field.Interface().(interface{ Validated() any }); ok {
+		return n.Validated()
+	}
+
+	return nil
+}
+
 // blacklist email validator
 func isEmailBlacklist(fl validator.FieldLevel) bool {
 	email := fl.Field().String()
@@ -131,3 +153,56 @@ func isUniqueInSlice(fl validator.FieldLevel) bool {
 	}
 	return true
 }
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// url slug validator, lowercase letters and digits separated by single hyphens, ex: "handphone-tablet"
+func isSlug(fl validator.FieldLevel) bool {
+	return slugRegex.MatchString(fl.Field().String())
+}
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// comma separated list of uuids validator, ex: "08362b22-f51d-40b1-a16b-49af90d561d9,3b4da768-e480-4cbb-b7fe-8b229123b50a"
+func isUuidCsv(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return false
+	}
+
+	for _, id := range strings.Split(value, ",") {
+		if !uuidRegex.MatchString(strings.TrimSpace(id)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// comma separated list validator where every item must be one of the space separated param values,
+// ex: `validate:"csv_oneof=shop category"` accepts "shop", "category" and "shop,category"
+func isCsvOneOf(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return false
+	}
+
+	allowed := strings.Fields(fl.Param())
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+
+		found := false
+		for _, a := range allowed {
+			if item == a {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
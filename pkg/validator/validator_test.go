@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"codebase-app/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUuidCsv(t *testing.T) {
+	type request struct {
+		Ids string `query:"ids" validate:"omitempty,uuid_csv"`
+	}
+
+	v := NewValidator()
+
+	assert.NoError(t, v.Validate(&request{}))
+	assert.NoError(t, v.Validate(&request{Ids: "08362b22-f51d-40b1-a16b-49af90d561d9"}))
+	assert.NoError(t, v.Validate(&request{Ids: "08362b22-f51d-40b1-a16b-49af90d561d9, 3b4da768-e480-4cbb-b7fe-8b229123b50a"}))
+	assert.Error(t, v.Validate(&request{Ids: "08362b22-f51d-40b1-a16b-49af90d561d9,abc"}))
+	assert.Error(t, v.Validate(&request{Ids: ","}))
+}
+
+func TestCsvOneOf(t *testing.T) {
+	type request struct {
+		Expand string `query:"expand" validate:"omitempty,csv_oneof=shop category"`
+	}
+
+	v := NewValidator()
+
+	assert.NoError(t, v.Validate(&request{}))
+	assert.NoError(t, v.Validate(&request{Expand: "shop"}))
+	assert.NoError(t, v.Validate(&request{Expand: "shop, category"}))
+	assert.Error(t, v.Validate(&request{Expand: "shop,owner"}))
+}
+
+func TestSlug(t *testing.T) {
+	type request struct {
+		Slug string `json:"slug" validate:"omitempty,slug"`
+	}
+
+	v := NewValidator()
+
+	assert.NoError(t, v.Validate(&request{}))
+	assert.NoError(t, v.Validate(&request{Slug: "handphone-tablet"}))
+	assert.NoError(t, v.Validate(&request{Slug: "tv4k"}))
+	assert.Error(t, v.Validate(&request{Slug: "Handphone"}))
+	assert.Error(t, v.Validate(&request{Slug: "handphone--tablet"}))
+	assert.Error(t, v.Validate(&request{Slug: "-handphone"}))
+}
+
+func TestNullable(t *testing.T) {
+	type request struct {
+		Threshold types.Nullable[int] `json:"threshold" validate:"omitempty,gte=0"`
+	}
+
+	var (
+		v        = NewValidator()
+		zero     = 0
+		negative = -1
+	)
+
+	assert.NoError(t, v.Validate(&request{}))
+	assert.NoError(t, v.Validate(&request{Threshold: types.Nullable[int]{Set: true}}))
+	assert.NoError(t, v.Validate(&request{Threshold: types.Nullable[int]{Set: true, Value: &zero}}))
+	assert.Error(t, v.Validate(&request{Threshold: types.Nullable[int]{Set: true, Value: &negative}}))
+}